@@ -25,6 +25,56 @@ func (c *CrawlClient) CrawlResult(ctx context.Context, in *pb.URLRequest, opts .
 	return c.client.CrawlResult(ctx, in, opts...)
 }
 
+// WatchCrawl allows us to follow a crawl as it happens.
+func (c *CrawlClient) WatchCrawl(ctx context.Context, in *pb.URLRequest, opts ...grpc.CallOption) (pb.Crawl_WatchCrawlClient, error) {
+	return c.client.WatchCrawl(ctx, in, opts...)
+}
+
+// ListCrawls allows us to see every crawl the server knows about.
+func (c *CrawlClient) ListCrawls(ctx context.Context, in *pb.ListRequest, opts ...grpc.CallOption) (*pb.CrawlList, error) {
+	return c.client.ListCrawls(ctx, in, opts...)
+}
+
+// DeleteCrawl allows us to get rid of crawls we're done with.
+func (c *CrawlClient) DeleteCrawl(ctx context.Context, in *pb.DeleteRequest, opts ...grpc.CallOption) (*pb.DeleteResponse, error) {
+	return c.client.DeleteCrawl(ctx, in, opts...)
+}
+
+// BrokenLinks allows us to find the links in a crawl that don't work.
+func (c *CrawlClient) BrokenLinks(ctx context.Context, in *pb.URLRequest, opts ...grpc.CallOption) (*pb.BrokenLinkReport, error) {
+	return c.client.BrokenLinks(ctx, in, opts...)
+}
+
+// BrokenAnchors allows us to find the links in a crawl to fragments
+// that aren't there.
+func (c *CrawlClient) BrokenAnchors(ctx context.Context, in *pb.URLRequest, opts ...grpc.CallOption) (*pb.BrokenAnchorReport, error) {
+	return c.client.BrokenAnchors(ctx, in, opts...)
+}
+
+// RedirectChains allows us to find the URLs in a crawl that redirect
+// more than once.
+func (c *CrawlClient) RedirectChains(ctx context.Context, in *pb.URLRequest, opts ...grpc.CallOption) (*pb.RedirectReport, error) {
+	return c.client.RedirectChains(ctx, in, opts...)
+}
+
+// Duplicates allows us to find the pages in a crawl that are the same
+// as other pages.
+func (c *CrawlClient) Duplicates(ctx context.Context, in *pb.URLRequest, opts ...grpc.CallOption) (*pb.DuplicateReport, error) {
+	return c.client.Duplicates(ctx, in, opts...)
+}
+
+// Audit allows us to find the pages in a crawl with problems a search
+// engine would notice.
+func (c *CrawlClient) Audit(ctx context.Context, in *pb.URLRequest, opts ...grpc.CallOption) (*pb.AuditReport, error) {
+	return c.client.Audit(ctx, in, opts...)
+}
+
+// Perf allows us to see how quickly a crawl's pages came back, and
+// which were slowest and heaviest.
+func (c *CrawlClient) Perf(ctx context.Context, in *pb.URLRequest, opts ...grpc.CallOption) (*pb.PerfReport, error) {
+	return c.client.Perf(ctx, in, opts...)
+}
+
 // New takes the gRPC connection data, connects to the server,
 // and returns a struct that the client methods can be called on.
 func New(serverAddr string, opts ...grpc.DialOption) *CrawlClient {
@@ -53,7 +53,65 @@ func (x URLRequestCommand) String() string {
 	return proto.EnumName(URLRequestCommand_name, int32(x))
 }
 func (URLRequestCommand) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_crawl_c886f2d72542114f, []int{0, 0}
+	return fileDescriptor_crawl_43381811b101b80f, []int{0, 0}
+}
+
+// Which hosts are part of the site. Only used by START.
+type URLRequest_HostPolicy int32
+
+const (
+	URLRequest_EXACT_HOST         URLRequest_HostPolicy = 0
+	URLRequest_SUBDOMAINS         URLRequest_HostPolicy = 1
+	URLRequest_REGISTRABLE_DOMAIN URLRequest_HostPolicy = 2
+)
+
+var URLRequest_HostPolicy_name = map[int32]string{
+	0: "EXACT_HOST",
+	1: "SUBDOMAINS",
+	2: "REGISTRABLE_DOMAIN",
+}
+var URLRequest_HostPolicy_value = map[string]int32{
+	"EXACT_HOST":         0,
+	"SUBDOMAINS":         1,
+	"REGISTRABLE_DOMAIN": 2,
+}
+
+func (x URLRequest_HostPolicy) String() string {
+	return proto.EnumName(URLRequest_HostPolicy_name, int32(x))
+}
+func (URLRequest_HostPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{0, 1}
+}
+
+// How URLs are normalized, so that the different ways of writing
+// a page's URL are crawled once. Only used by START.
+type URLRequest_NormalizeFlags int32
+
+const (
+	URLRequest_DEFAULT_FLAGS      URLRequest_NormalizeFlags = 0
+	URLRequest_SAFE_FLAGS         URLRequest_NormalizeFlags = 1
+	URLRequest_USUALLY_SAFE_FLAGS URLRequest_NormalizeFlags = 2
+	URLRequest_UNSAFE_FLAGS       URLRequest_NormalizeFlags = 3
+)
+
+var URLRequest_NormalizeFlags_name = map[int32]string{
+	0: "DEFAULT_FLAGS",
+	1: "SAFE_FLAGS",
+	2: "USUALLY_SAFE_FLAGS",
+	3: "UNSAFE_FLAGS",
+}
+var URLRequest_NormalizeFlags_value = map[string]int32{
+	"DEFAULT_FLAGS":      0,
+	"SAFE_FLAGS":         1,
+	"USUALLY_SAFE_FLAGS": 2,
+	"UNSAFE_FLAGS":       3,
+}
+
+func (x URLRequest_NormalizeFlags) String() string {
+	return proto.EnumName(URLRequest_NormalizeFlags_name, int32(x))
+}
+func (URLRequest_NormalizeFlags) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{0, 2}
 }
 
 type URLState_Status int32
@@ -68,42 +126,235 @@ const (
 	// CRAWLING URL is a no-op. STOP for a CRAWLING
 	// URL saves the URL's state and sets it to STOPPED.
 	URLState_UNKNOWN URLState_Status = 2
+	// This is a meta-state; URLs never crawled
+	// are not recorded in the client to avoid a
+	// possible DoS from a clog of never-crawled URLs.
+	// Only returned for a STOP.
+	URLState_DONE          URLState_Status = 3
+	URLState_FAILED        URLState_Status = 4
+	URLState_LIMIT_REACHED URLState_Status = 5
 )
 
 var URLState_Status_name = map[int32]string{
 	0: "STOPPED",
 	1: "RUNNING",
 	2: "UNKNOWN",
+	3: "DONE",
+	4: "FAILED",
+	5: "LIMIT_REACHED",
 }
 var URLState_Status_value = map[string]int32{
-	"STOPPED": 0,
-	"RUNNING": 1,
-	"UNKNOWN": 2,
+	"STOPPED":       0,
+	"RUNNING":       1,
+	"UNKNOWN":       2,
+	"DONE":          3,
+	"FAILED":        4,
+	"LIMIT_REACHED": 5,
 }
 
 func (x URLState_Status) String() string {
 	return proto.EnumName(URLState_Status_name, int32(x))
 }
 func (URLState_Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_crawl_c886f2d72542114f, []int{1, 0}
+	return fileDescriptor_crawl_43381811b101b80f, []int{1, 0}
+}
+
+type SiteNode_Outcome int32
+
+const (
+	SiteNode_PENDING      SiteNode_Outcome = 0
+	SiteNode_FETCHING     SiteNode_Outcome = 1
+	SiteNode_FETCHED      SiteNode_Outcome = 2
+	SiteNode_FAILED       SiteNode_Outcome = 3
+	SiteNode_INVALID      SiteNode_Outcome = 4
+	SiteNode_OFFSITE      SiteNode_Outcome = 5
+	SiteNode_BLOCKED      SiteNode_Outcome = 6
+	SiteNode_UNFETCHED    SiteNode_Outcome = 7
+	SiteNode_OUT_OF_SCOPE SiteNode_Outcome = 8
+	SiteNode_REDIRECTED   SiteNode_Outcome = 9
+	SiteNode_DUPLICATE    SiteNode_Outcome = 10
+)
+
+var SiteNode_Outcome_name = map[int32]string{
+	0:  "PENDING",
+	1:  "FETCHING",
+	2:  "FETCHED",
+	3:  "FAILED",
+	4:  "INVALID",
+	5:  "OFFSITE",
+	6:  "BLOCKED",
+	7:  "UNFETCHED",
+	8:  "OUT_OF_SCOPE",
+	9:  "REDIRECTED",
+	10: "DUPLICATE",
+}
+var SiteNode_Outcome_value = map[string]int32{
+	"PENDING":      0,
+	"FETCHING":     1,
+	"FETCHED":      2,
+	"FAILED":       3,
+	"INVALID":      4,
+	"OFFSITE":      5,
+	"BLOCKED":      6,
+	"UNFETCHED":    7,
+	"OUT_OF_SCOPE": 8,
+	"REDIRECTED":   9,
+	"DUPLICATE":    10,
+}
+
+func (x SiteNode_Outcome) String() string {
+	return proto.EnumName(SiteNode_Outcome_name, int32(x))
+}
+func (SiteNode_Outcome) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{2, 0}
+}
+
+// What sort of thing went wrong, when something did.
+type SiteNode_ErrorKind int32
+
+const (
+	SiteNode_NO_ERROR           SiteNode_ErrorKind = 0
+	SiteNode_OTHER_ERROR        SiteNode_ErrorKind = 1
+	SiteNode_HTTP_ERROR         SiteNode_ErrorKind = 2
+	SiteNode_DNS_ERROR          SiteNode_ErrorKind = 3
+	SiteNode_CONNECTION_ERROR   SiteNode_ErrorKind = 4
+	SiteNode_TIMEOUT            SiteNode_ErrorKind = 5
+	SiteNode_INVALID_URL        SiteNode_ErrorKind = 6
+	SiteNode_REDIRECT_LOOP      SiteNode_ErrorKind = 7
+	SiteNode_TOO_MANY_REDIRECTS SiteNode_ErrorKind = 8
+)
+
+var SiteNode_ErrorKind_name = map[int32]string{
+	0: "NO_ERROR",
+	1: "OTHER_ERROR",
+	2: "HTTP_ERROR",
+	3: "DNS_ERROR",
+	4: "CONNECTION_ERROR",
+	5: "TIMEOUT",
+	6: "INVALID_URL",
+	7: "REDIRECT_LOOP",
+	8: "TOO_MANY_REDIRECTS",
+}
+var SiteNode_ErrorKind_value = map[string]int32{
+	"NO_ERROR":           0,
+	"OTHER_ERROR":        1,
+	"HTTP_ERROR":         2,
+	"DNS_ERROR":          3,
+	"CONNECTION_ERROR":   4,
+	"TIMEOUT":            5,
+	"INVALID_URL":        6,
+	"REDIRECT_LOOP":      7,
+	"TOO_MANY_REDIRECTS": 8,
+}
+
+func (x SiteNode_ErrorKind) String() string {
+	return proto.EnumName(SiteNode_ErrorKind_name, int32(x))
+}
+func (SiteNode_ErrorKind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{2, 1}
+}
+
+type CrawlEvent_Kind int32
+
+const (
+	CrawlEvent_QUEUED        CrawlEvent_Kind = 0
+	CrawlEvent_FETCHED       CrawlEvent_Kind = 1
+	CrawlEvent_FAILED        CrawlEvent_Kind = 2
+	CrawlEvent_STATE_CHANGED CrawlEvent_Kind = 3
+)
+
+var CrawlEvent_Kind_name = map[int32]string{
+	0: "QUEUED",
+	1: "FETCHED",
+	2: "FAILED",
+	3: "STATE_CHANGED",
+}
+var CrawlEvent_Kind_value = map[string]int32{
+	"QUEUED":        0,
+	"FETCHED":       1,
+	"FAILED":        2,
+	"STATE_CHANGED": 3,
+}
+
+func (x CrawlEvent_Kind) String() string {
+	return proto.EnumName(CrawlEvent_Kind_name, int32(x))
+}
+func (CrawlEvent_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{20, 0}
 }
 
 // URLRequest defines the outgoing request.
 // We can provide a URL and the state we want the client
 // to put it in.
 type URLRequest struct {
-	URL                  string            `protobuf:"bytes,1,opt,name=URL,proto3" json:"URL,omitempty"`
-	State                URLRequestCommand `protobuf:"varint,2,opt,name=state,proto3,enum=crawl.URLRequestCommand" json:"state,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	URL   string            `protobuf:"bytes,1,opt,name=URL,proto3" json:"URL,omitempty"`
+	State URLRequestCommand `protobuf:"varint,2,opt,name=state,proto3,enum=crawl.URLRequestCommand" json:"state,omitempty"`
+	// Number of pages to fetch in parallel for this crawl.
+	// Zero means use the server's default. Only used by START.
+	Workers int32 `protobuf:"varint,3,opt,name=workers,proto3" json:"workers,omitempty"`
+	// Politeness limits for each host this crawl visits: at most
+	// requestsPerSecond (in bursts of up to burst requests), and
+	// at least minDelayMillis between requests. A setting is used
+	// if it's nonzero or its ...Set field is true (so that zero can
+	// turn a limit off); otherwise the server's default is. Crawls
+	// of the same host running at the same time share these limits,
+	// with the strictest settings winning. Only used by START.
+	RequestsPerSecond    float64 `protobuf:"fixed64,4,opt,name=requestsPerSecond,proto3" json:"requestsPerSecond,omitempty"`
+	Burst                int32   `protobuf:"varint,5,opt,name=burst,proto3" json:"burst,omitempty"`
+	MinDelayMillis       int64   `protobuf:"varint,6,opt,name=minDelayMillis,proto3" json:"minDelayMillis,omitempty"`
+	RequestsPerSecondSet bool    `protobuf:"varint,24,opt,name=requestsPerSecondSet,proto3" json:"requestsPerSecondSet,omitempty"`
+	BurstSet             bool    `protobuf:"varint,25,opt,name=burstSet,proto3" json:"burstSet,omitempty"`
+	MinDelaySet          bool    `protobuf:"varint,26,opt,name=minDelaySet,proto3" json:"minDelaySet,omitempty"`
+	// Crawl pages even if robots.txt disallows them. Only for
+	// sites we own. Only used by START.
+	IgnoreRobots bool `protobuf:"varint,7,opt,name=ignoreRobots,proto3" json:"ignoreRobots,omitempty"`
+	// Also crawl every page listed in the site's sitemaps, and
+	// report the ones nothing links to. Only used by START.
+	Sitemaps bool `protobuf:"varint,8,opt,name=sitemaps,proto3" json:"sitemaps,omitempty"`
+	// Limits on the crawl: how many links to follow away from the
+	// root, how many pages to fetch, and how long to run. Zero means
+	// no limit. When a limit is reached the crawl ends in
+	// LIMIT_REACHED. Only used by START.
+	MaxDepth       int32 `protobuf:"varint,9,opt,name=maxDepth,proto3" json:"maxDepth,omitempty"`
+	MaxPages       int32 `protobuf:"varint,10,opt,name=maxPages,proto3" json:"maxPages,omitempty"`
+	TimeoutSeconds int64 `protobuf:"varint,11,opt,name=timeoutSeconds,proto3" json:"timeoutSeconds,omitempty"`
+	// Patterns limiting the crawl to part of the site, matched against
+	// the path and query of each URL. Globs, where * matches anything,
+	// or regular expressions prefixed with "re:". A URL is crawled if
+	// it matches an include pattern (or there are none) and no exclude
+	// pattern; the others are reported as OUT_OF_SCOPE. Only used by START.
+	Include    []string              `protobuf:"bytes,12,rep,name=include,proto3" json:"include,omitempty"`
+	Exclude    []string              `protobuf:"bytes,13,rep,name=exclude,proto3" json:"exclude,omitempty"`
+	HostPolicy URLRequest_HostPolicy `protobuf:"varint,14,opt,name=hostPolicy,proto3,enum=crawl.URLRequest_HostPolicy" json:"hostPolicy,omitempty"`
+	// Other hosts that are the same site, whatever the policy.
+	HostAliases []string `protobuf:"bytes,15,rep,name=hostAliases,proto3" json:"hostAliases,omitempty"`
+	// Treat http and https URLs as the same site.
+	AnyScheme bool `protobuf:"varint,16,opt,name=anyScheme,proto3" json:"anyScheme,omitempty"`
+	// Check the images, scripts, stylesheets and other assets pages
+	// use, as well as the pages they link to. Only used by START.
+	Assets         bool                      `protobuf:"varint,17,opt,name=assets,proto3" json:"assets,omitempty"`
+	NormalizeFlags URLRequest_NormalizeFlags `protobuf:"varint,18,opt,name=normalizeFlags,proto3,enum=crawl.URLRequest_NormalizeFlags" json:"normalizeFlags,omitempty"`
+	// Query parameters to remove; "utm_*" removes every one starting "utm_".
+	StripParams []string `protobuf:"bytes,19,rep,name=stripParams,proto3" json:"stripParams,omitempty"`
+	// Sort the query parameters by name.
+	SortQuery bool `protobuf:"varint,20,opt,name=sortQuery,proto3" json:"sortQuery,omitempty"`
+	// Lowercase paths, for sites where case doesn't matter.
+	LowercasePaths bool `protobuf:"varint,21,opt,name=lowercasePaths,proto3" json:"lowercasePaths,omitempty"`
+	// Treat "/a" and "/a/" as different pages.
+	KeepTrailingSlash bool `protobuf:"varint,22,opt,name=keepTrailingSlash,proto3" json:"keepTrailingSlash,omitempty"`
+	// How many of the slowest and heaviest pages Perf lists. Zero
+	// means 10. Only used by Perf.
+	Top                  int32    `protobuf:"varint,23,opt,name=top,proto3" json:"top,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *URLRequest) Reset()         { *m = URLRequest{} }
 func (m *URLRequest) String() string { return proto.CompactTextString(m) }
 func (*URLRequest) ProtoMessage()    {}
 func (*URLRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_crawl_c886f2d72542114f, []int{0}
+	return fileDescriptor_crawl_43381811b101b80f, []int{0}
 }
 func (m *URLRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_URLRequest.Unmarshal(m, b)
@@ -137,20 +388,192 @@ func (m *URLRequest) GetState() URLRequestCommand {
 	return URLRequest_START
 }
 
+func (m *URLRequest) GetWorkers() int32 {
+	if m != nil {
+		return m.Workers
+	}
+	return 0
+}
+
+func (m *URLRequest) GetRequestsPerSecond() float64 {
+	if m != nil {
+		return m.RequestsPerSecond
+	}
+	return 0
+}
+
+func (m *URLRequest) GetBurst() int32 {
+	if m != nil {
+		return m.Burst
+	}
+	return 0
+}
+
+func (m *URLRequest) GetMinDelayMillis() int64 {
+	if m != nil {
+		return m.MinDelayMillis
+	}
+	return 0
+}
+
+func (m *URLRequest) GetRequestsPerSecondSet() bool {
+	if m != nil {
+		return m.RequestsPerSecondSet
+	}
+	return false
+}
+
+func (m *URLRequest) GetBurstSet() bool {
+	if m != nil {
+		return m.BurstSet
+	}
+	return false
+}
+
+func (m *URLRequest) GetMinDelaySet() bool {
+	if m != nil {
+		return m.MinDelaySet
+	}
+	return false
+}
+
+func (m *URLRequest) GetIgnoreRobots() bool {
+	if m != nil {
+		return m.IgnoreRobots
+	}
+	return false
+}
+
+func (m *URLRequest) GetSitemaps() bool {
+	if m != nil {
+		return m.Sitemaps
+	}
+	return false
+}
+
+func (m *URLRequest) GetMaxDepth() int32 {
+	if m != nil {
+		return m.MaxDepth
+	}
+	return 0
+}
+
+func (m *URLRequest) GetMaxPages() int32 {
+	if m != nil {
+		return m.MaxPages
+	}
+	return 0
+}
+
+func (m *URLRequest) GetTimeoutSeconds() int64 {
+	if m != nil {
+		return m.TimeoutSeconds
+	}
+	return 0
+}
+
+func (m *URLRequest) GetInclude() []string {
+	if m != nil {
+		return m.Include
+	}
+	return nil
+}
+
+func (m *URLRequest) GetExclude() []string {
+	if m != nil {
+		return m.Exclude
+	}
+	return nil
+}
+
+func (m *URLRequest) GetHostPolicy() URLRequest_HostPolicy {
+	if m != nil {
+		return m.HostPolicy
+	}
+	return URLRequest_EXACT_HOST
+}
+
+func (m *URLRequest) GetHostAliases() []string {
+	if m != nil {
+		return m.HostAliases
+	}
+	return nil
+}
+
+func (m *URLRequest) GetAnyScheme() bool {
+	if m != nil {
+		return m.AnyScheme
+	}
+	return false
+}
+
+func (m *URLRequest) GetAssets() bool {
+	if m != nil {
+		return m.Assets
+	}
+	return false
+}
+
+func (m *URLRequest) GetNormalizeFlags() URLRequest_NormalizeFlags {
+	if m != nil {
+		return m.NormalizeFlags
+	}
+	return URLRequest_DEFAULT_FLAGS
+}
+
+func (m *URLRequest) GetStripParams() []string {
+	if m != nil {
+		return m.StripParams
+	}
+	return nil
+}
+
+func (m *URLRequest) GetSortQuery() bool {
+	if m != nil {
+		return m.SortQuery
+	}
+	return false
+}
+
+func (m *URLRequest) GetLowercasePaths() bool {
+	if m != nil {
+		return m.LowercasePaths
+	}
+	return false
+}
+
+func (m *URLRequest) GetKeepTrailingSlash() bool {
+	if m != nil {
+		return m.KeepTrailingSlash
+	}
+	return false
+}
+
+func (m *URLRequest) GetTop() int32 {
+	if m != nil {
+		return m.Top
+	}
+	return 0
+}
+
 // URLState reports the crawl status ONLY of a URL.
 type URLState struct {
-	Status               URLState_Status `protobuf:"varint,1,opt,name=status,proto3,enum=crawl.URLState_Status" json:"status,omitempty"`
-	Message              string          `protobuf:"bytes,2,opt,name=Message,proto3" json:"Message,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Status  URLState_Status `protobuf:"varint,1,opt,name=status,proto3,enum=crawl.URLState_Status" json:"status,omitempty"`
+	Message string          `protobuf:"bytes,2,opt,name=Message,proto3" json:"Message,omitempty"`
+	// For LIMIT_REACHED, the URLs that were found but not fetched.
+	Frontier []string `protobuf:"bytes,3,rep,name=frontier,proto3" json:"frontier,omitempty"`
+	// For CHECK, how quickly the crawl's pages have come back so far.
+	Perf                 *CrawlPerf `protobuf:"bytes,4,opt,name=perf,proto3" json:"perf,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *URLState) Reset()         { *m = URLState{} }
 func (m *URLState) String() string { return proto.CompactTextString(m) }
 func (*URLState) ProtoMessage()    {}
 func (*URLState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_crawl_c886f2d72542114f, []int{1}
+	return fileDescriptor_crawl_43381811b101b80f, []int{1}
 }
 func (m *URLState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_URLState.Unmarshal(m, b)
@@ -184,16 +607,84 @@ func (m *URLState) GetMessage() string {
 	return ""
 }
 
+func (m *URLState) GetFrontier() []string {
+	if m != nil {
+		return m.Frontier
+	}
+	return nil
+}
+
+func (m *URLState) GetPerf() *CrawlPerf {
+	if m != nil {
+		return m.Perf
+	}
+	return nil
+}
+
 // SiteNode is returned in response to a STATUS request.
 // It returns a tree of sitenodes found under the current
 // URL (which may recursively contain more SiteNodes).
+// Each URL appears in the tree once, under the page it
+// was first found on.
 // If no URL is supplied, all the SiteNodes the crawler
 // knows about are returned as the children of a SiteNode
 // with the siteURL "all://".
 type SiteNode struct {
-	SiteURL              string   `protobuf:"bytes,1,opt,name=siteURL,proto3" json:"siteURL,omitempty"`
-	TreeString           string   `protobuf:"bytes,2,opt,name=treeString,proto3" json:"treeString,omitempty"`
-	Status               string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	SiteURL string `protobuf:"bytes,1,opt,name=siteURL,proto3" json:"siteURL,omitempty"`
+	// The state of the crawl. Only set on the root.
+	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// The URL of the page this one was found on; empty for the root.
+	Parent string `protobuf:"bytes,4,opt,name=parent,proto3" json:"parent,omitempty"`
+	// Number of links between the root and this URL.
+	Depth   int32            `protobuf:"varint,5,opt,name=depth,proto3" json:"depth,omitempty"`
+	Outcome SiteNode_Outcome `protobuf:"varint,6,opt,name=outcome,proto3,enum=crawl.SiteNode_Outcome" json:"outcome,omitempty"`
+	// The HTTP status of the fetch; zero if unknown.
+	HttpStatus int32 `protobuf:"varint,7,opt,name=httpStatus,proto3" json:"httpStatus,omitempty"`
+	// Why the fetch failed, for FAILED and INVALID.
+	Error string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	// The URL was found in the site's sitemap, rather than linked to.
+	Sitemap bool `protobuf:"varint,9,opt,name=sitemap,proto3" json:"sitemap,omitempty"`
+	// The URL is in the sitemap, but no crawled page links to it.
+	Orphaned bool `protobuf:"varint,10,opt,name=orphaned,proto3" json:"orphaned,omitempty"`
+	// Not set by CrawlResult, which sends the tree a node at a time;
+	// see level.
+	Children []*SiteNode `protobuf:"bytes,11,rep,name=children,proto3" json:"children,omitempty"`
+	// Every link to this URL the crawl found, not just the one
+	// that put it in the tree.
+	LinkedFrom []*Link `protobuf:"bytes,12,rep,name=linkedFrom,proto3" json:"linkedFrom,omitempty"`
+	// Where the fetch ended up, after any redirects.
+	FinalURL    string `protobuf:"bytes,13,opt,name=finalURL,proto3" json:"finalURL,omitempty"`
+	ContentType string `protobuf:"bytes,14,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	// Size of the response body, in bytes.
+	Size int64 `protobuf:"varint,15,opt,name=size,proto3" json:"size,omitempty"`
+	// How long the fetch took.
+	LatencyMillis int64              `protobuf:"varint,16,opt,name=latencyMillis,proto3" json:"latencyMillis,omitempty"`
+	ErrorKind     SiteNode_ErrorKind `protobuf:"varint,17,opt,name=errorKind,proto3,enum=crawl.SiteNode_ErrorKind" json:"errorKind,omitempty"`
+	// The kind of asset the URL is ("image", "script", "stylesheet",
+	// "icon", "media" or "frame"); empty for a page. Assets are
+	// checked, not parsed, so they have no children.
+	Asset string `protobuf:"bytes,18,opt,name=asset,proto3" json:"asset,omitempty"`
+	// The fragments links can point at on the page: its ids and
+	// <a name>s.
+	Anchors []string `protobuf:"bytes,19,rep,name=anchors,proto3" json:"anchors,omitempty"`
+	// The redirects the fetch went through, in order.
+	Redirects []*Redirect `protobuf:"bytes,20,rep,name=redirects,proto3" json:"redirects,omitempty"`
+	// The URL the page names as its canonical one, if any.
+	Canonical string `protobuf:"bytes,21,opt,name=canonical,proto3" json:"canonical,omitempty"`
+	// A fingerprint of the page's text, for spotting duplicates.
+	ContentHash string `protobuf:"bytes,22,opt,name=contentHash,proto3" json:"contentHash,omitempty"`
+	// For DUPLICATE, the page this one is the same as, and how we
+	// know: "canonical" or "same content".
+	DuplicateOf     string `protobuf:"bytes,23,opt,name=duplicateOf,proto3" json:"duplicateOf,omitempty"`
+	DuplicateReason string `protobuf:"bytes,24,opt,name=duplicateReason,proto3" json:"duplicateReason,omitempty"`
+	// What a fetched page says about itself.
+	Meta *PageMeta `protobuf:"bytes,25,opt,name=meta,proto3" json:"meta,omitempty"`
+	// How long before the response started to arrive.
+	FirstByteMillis int64 `protobuf:"varint,26,opt,name=firstByteMillis,proto3" json:"firstByteMillis,omitempty"`
+	// Where the node goes in the tree CrawlResult sends: 0 for the
+	// root, and one more than its parent's for everything else. The
+	// parent is the last node sent before it with a lower level.
+	Level                int32    `protobuf:"varint,27,opt,name=level,proto3" json:"level,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -203,7 +694,7 @@ func (m *SiteNode) Reset()         { *m = SiteNode{} }
 func (m *SiteNode) String() string { return proto.CompactTextString(m) }
 func (*SiteNode) ProtoMessage()    {}
 func (*SiteNode) Descriptor() ([]byte, []int) {
-	return fileDescriptor_crawl_c886f2d72542114f, []int{2}
+	return fileDescriptor_crawl_43381811b101b80f, []int{2}
 }
 func (m *SiteNode) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SiteNode.Unmarshal(m, b)
@@ -230,109 +721,1807 @@ func (m *SiteNode) GetSiteURL() string {
 	return ""
 }
 
-func (m *SiteNode) GetTreeString() string {
+func (m *SiteNode) GetStatus() string {
 	if m != nil {
-		return m.TreeString
+		return m.Status
 	}
 	return ""
 }
 
-func (m *SiteNode) GetStatus() string {
+func (m *SiteNode) GetParent() string {
 	if m != nil {
-		return m.Status
+		return m.Parent
 	}
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*URLRequest)(nil), "crawl.URLRequest")
-	proto.RegisterType((*URLState)(nil), "crawl.URLState")
-	proto.RegisterType((*SiteNode)(nil), "crawl.SiteNode")
-	proto.RegisterEnum("crawl.URLRequestCommand", URLRequestCommand_name, URLRequestCommand_value)
-	proto.RegisterEnum("crawl.URLState_Status", URLState_Status_name, URLState_Status_value)
+func (m *SiteNode) GetDepth() int32 {
+	if m != nil {
+		return m.Depth
+	}
+	return 0
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *SiteNode) GetOutcome() SiteNode_Outcome {
+	if m != nil {
+		return m.Outcome
+	}
+	return SiteNode_PENDING
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *SiteNode) GetHttpStatus() int32 {
+	if m != nil {
+		return m.HttpStatus
+	}
+	return 0
+}
 
-// CrawlClient is the client API for Crawl service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type CrawlClient interface {
-	// Because we're calling the client from our CLI, we
-	// want the CrawlSite API to make a single request
-	// and wait for the response. This API lets us start,
-	// stop, or check the status of a URL
-	CrawlSite(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (*URLState, error)
-	// Checks the current status of a crawl and returns
-	// the tree as it stands.
-	CrawlResult(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (Crawl_CrawlResultClient, error)
+func (m *SiteNode) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
 }
 
-type crawlClient struct {
-	cc *grpc.ClientConn
+func (m *SiteNode) GetSitemap() bool {
+	if m != nil {
+		return m.Sitemap
+	}
+	return false
 }
 
-func NewCrawlClient(cc *grpc.ClientConn) CrawlClient {
-	return &crawlClient{cc}
+func (m *SiteNode) GetOrphaned() bool {
+	if m != nil {
+		return m.Orphaned
+	}
+	return false
 }
 
-func (c *crawlClient) CrawlSite(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (*URLState, error) {
-	out := new(URLState)
-	err := c.cc.Invoke(ctx, "/crawl.Crawl/CrawlSite", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *SiteNode) GetChildren() []*SiteNode {
+	if m != nil {
+		return m.Children
 	}
-	return out, nil
+	return nil
 }
 
-func (c *crawlClient) CrawlResult(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (Crawl_CrawlResultClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Crawl_serviceDesc.Streams[0], "/crawl.Crawl/CrawlResult", opts...)
-	if err != nil {
-		return nil, err
+func (m *SiteNode) GetLinkedFrom() []*Link {
+	if m != nil {
+		return m.LinkedFrom
 	}
-	x := &crawlCrawlResultClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	return nil
+}
+
+func (m *SiteNode) GetFinalURL() string {
+	if m != nil {
+		return m.FinalURL
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	return ""
+}
+
+func (m *SiteNode) GetContentType() string {
+	if m != nil {
+		return m.ContentType
 	}
-	return x, nil
+	return ""
 }
 
-type Crawl_CrawlResultClient interface {
-	Recv() (*SiteNode, error)
-	grpc.ClientStream
+func (m *SiteNode) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
 }
 
-type crawlCrawlResultClient struct {
-	grpc.ClientStream
+func (m *SiteNode) GetLatencyMillis() int64 {
+	if m != nil {
+		return m.LatencyMillis
+	}
+	return 0
 }
 
-func (x *crawlCrawlResultClient) Recv() (*SiteNode, error) {
-	m := new(SiteNode)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *SiteNode) GetErrorKind() SiteNode_ErrorKind {
+	if m != nil {
+		return m.ErrorKind
 	}
-	return m, nil
+	return SiteNode_NO_ERROR
 }
 
-// CrawlServer is the server API for Crawl service.
-type CrawlServer interface {
-	// Because we're calling the client from our CLI, we
-	// want the CrawlSite API to make a single request
-	// and wait for the response. This API lets us start,
+func (m *SiteNode) GetAsset() string {
+	if m != nil {
+		return m.Asset
+	}
+	return ""
+}
+
+func (m *SiteNode) GetAnchors() []string {
+	if m != nil {
+		return m.Anchors
+	}
+	return nil
+}
+
+func (m *SiteNode) GetRedirects() []*Redirect {
+	if m != nil {
+		return m.Redirects
+	}
+	return nil
+}
+
+func (m *SiteNode) GetCanonical() string {
+	if m != nil {
+		return m.Canonical
+	}
+	return ""
+}
+
+func (m *SiteNode) GetContentHash() string {
+	if m != nil {
+		return m.ContentHash
+	}
+	return ""
+}
+
+func (m *SiteNode) GetDuplicateOf() string {
+	if m != nil {
+		return m.DuplicateOf
+	}
+	return ""
+}
+
+func (m *SiteNode) GetDuplicateReason() string {
+	if m != nil {
+		return m.DuplicateReason
+	}
+	return ""
+}
+
+func (m *SiteNode) GetMeta() *PageMeta {
+	if m != nil {
+		return m.Meta
+	}
+	return nil
+}
+
+func (m *SiteNode) GetFirstByteMillis() int64 {
+	if m != nil {
+		return m.FirstByteMillis
+	}
+	return 0
+}
+
+func (m *SiteNode) GetLevel() int32 {
+	if m != nil {
+		return m.Level
+	}
+	return 0
+}
+
+// What a page says about itself: the things a search engine looks at.
+type PageMeta struct {
+	Title       string   `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	H1S         []string `protobuf:"bytes,3,rep,name=h1s,proto3" json:"h1s,omitempty"`
+	// The content of the page's <meta name="robots">.
+	Robots string `protobuf:"bytes,4,opt,name=robots,proto3" json:"robots,omitempty"`
+	// The page's <html lang>.
+	Language string `protobuf:"bytes,5,opt,name=language,proto3" json:"language,omitempty"`
+	Words    int32  `protobuf:"varint,6,opt,name=words,proto3" json:"words,omitempty"`
+	// Links on the page to other pages.
+	OutboundLinks        int32    `protobuf:"varint,7,opt,name=outboundLinks,proto3" json:"outboundLinks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PageMeta) Reset()         { *m = PageMeta{} }
+func (m *PageMeta) String() string { return proto.CompactTextString(m) }
+func (*PageMeta) ProtoMessage()    {}
+func (*PageMeta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{3}
+}
+func (m *PageMeta) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PageMeta.Unmarshal(m, b)
+}
+func (m *PageMeta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PageMeta.Marshal(b, m, deterministic)
+}
+func (dst *PageMeta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PageMeta.Merge(dst, src)
+}
+func (m *PageMeta) XXX_Size() int {
+	return xxx_messageInfo_PageMeta.Size(m)
+}
+func (m *PageMeta) XXX_DiscardUnknown() {
+	xxx_messageInfo_PageMeta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PageMeta proto.InternalMessageInfo
+
+func (m *PageMeta) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *PageMeta) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *PageMeta) GetH1S() []string {
+	if m != nil {
+		return m.H1S
+	}
+	return nil
+}
+
+func (m *PageMeta) GetRobots() string {
+	if m != nil {
+		return m.Robots
+	}
+	return ""
+}
+
+func (m *PageMeta) GetLanguage() string {
+	if m != nil {
+		return m.Language
+	}
+	return ""
+}
+
+func (m *PageMeta) GetWords() int32 {
+	if m != nil {
+		return m.Words
+	}
+	return 0
+}
+
+func (m *PageMeta) GetOutboundLinks() int32 {
+	if m != nil {
+		return m.OutboundLinks
+	}
+	return 0
+}
+
+// One hop in a chain of redirects.
+type Redirect struct {
+	URL        string `protobuf:"bytes,1,opt,name=URL,proto3" json:"URL,omitempty"`
+	HttpStatus int32  `protobuf:"varint,2,opt,name=httpStatus,proto3" json:"httpStatus,omitempty"`
+	// Where the redirect sent us.
+	Location             string   `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Redirect) Reset()         { *m = Redirect{} }
+func (m *Redirect) String() string { return proto.CompactTextString(m) }
+func (*Redirect) ProtoMessage()    {}
+func (*Redirect) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{4}
+}
+func (m *Redirect) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Redirect.Unmarshal(m, b)
+}
+func (m *Redirect) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Redirect.Marshal(b, m, deterministic)
+}
+func (dst *Redirect) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Redirect.Merge(dst, src)
+}
+func (m *Redirect) XXX_Size() int {
+	return xxx_messageInfo_Redirect.Size(m)
+}
+func (m *Redirect) XXX_DiscardUnknown() {
+	xxx_messageInfo_Redirect.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Redirect proto.InternalMessageInfo
+
+func (m *Redirect) GetURL() string {
+	if m != nil {
+		return m.URL
+	}
+	return ""
+}
+
+func (m *Redirect) GetHttpStatus() int32 {
+	if m != nil {
+		return m.HttpStatus
+	}
+	return 0
+}
+
+func (m *Redirect) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+// Link is a link to a page: the page it's on, and its anchor text.
+type Link struct {
+	Source               string   `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Text                 string   `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Link) Reset()         { *m = Link{} }
+func (m *Link) String() string { return proto.CompactTextString(m) }
+func (*Link) ProtoMessage()    {}
+func (*Link) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{5}
+}
+func (m *Link) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Link.Unmarshal(m, b)
+}
+func (m *Link) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Link.Marshal(b, m, deterministic)
+}
+func (dst *Link) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Link.Merge(dst, src)
+}
+func (m *Link) XXX_Size() int {
+	return xxx_messageInfo_Link.Size(m)
+}
+func (m *Link) XXX_DiscardUnknown() {
+	xxx_messageInfo_Link.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Link proto.InternalMessageInfo
+
+func (m *Link) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *Link) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+// A URL the crawl couldn't fetch.
+type BrokenLink struct {
+	URL string `protobuf:"bytes,1,opt,name=URL,proto3" json:"URL,omitempty"`
+	// FAILED or INVALID.
+	Outcome    SiteNode_Outcome   `protobuf:"varint,2,opt,name=outcome,proto3,enum=crawl.SiteNode_Outcome" json:"outcome,omitempty"`
+	HttpStatus int32              `protobuf:"varint,3,opt,name=httpStatus,proto3" json:"httpStatus,omitempty"`
+	Error      string             `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorKind  SiteNode_ErrorKind `protobuf:"varint,5,opt,name=errorKind,proto3,enum=crawl.SiteNode_ErrorKind" json:"errorKind,omitempty"`
+	// Every link to the URL; empty if it's the root.
+	LinkedFrom           []*Link  `protobuf:"bytes,6,rep,name=linkedFrom,proto3" json:"linkedFrom,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BrokenLink) Reset()         { *m = BrokenLink{} }
+func (m *BrokenLink) String() string { return proto.CompactTextString(m) }
+func (*BrokenLink) ProtoMessage()    {}
+func (*BrokenLink) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{6}
+}
+func (m *BrokenLink) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BrokenLink.Unmarshal(m, b)
+}
+func (m *BrokenLink) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BrokenLink.Marshal(b, m, deterministic)
+}
+func (dst *BrokenLink) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BrokenLink.Merge(dst, src)
+}
+func (m *BrokenLink) XXX_Size() int {
+	return xxx_messageInfo_BrokenLink.Size(m)
+}
+func (m *BrokenLink) XXX_DiscardUnknown() {
+	xxx_messageInfo_BrokenLink.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BrokenLink proto.InternalMessageInfo
+
+func (m *BrokenLink) GetURL() string {
+	if m != nil {
+		return m.URL
+	}
+	return ""
+}
+
+func (m *BrokenLink) GetOutcome() SiteNode_Outcome {
+	if m != nil {
+		return m.Outcome
+	}
+	return SiteNode_PENDING
+}
+
+func (m *BrokenLink) GetHttpStatus() int32 {
+	if m != nil {
+		return m.HttpStatus
+	}
+	return 0
+}
+
+func (m *BrokenLink) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *BrokenLink) GetErrorKind() SiteNode_ErrorKind {
+	if m != nil {
+		return m.ErrorKind
+	}
+	return SiteNode_NO_ERROR
+}
+
+func (m *BrokenLink) GetLinkedFrom() []*Link {
+	if m != nil {
+		return m.LinkedFrom
+	}
+	return nil
+}
+
+type BrokenLinkReport struct {
+	Links                []*BrokenLink `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *BrokenLinkReport) Reset()         { *m = BrokenLinkReport{} }
+func (m *BrokenLinkReport) String() string { return proto.CompactTextString(m) }
+func (*BrokenLinkReport) ProtoMessage()    {}
+func (*BrokenLinkReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{7}
+}
+func (m *BrokenLinkReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BrokenLinkReport.Unmarshal(m, b)
+}
+func (m *BrokenLinkReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BrokenLinkReport.Marshal(b, m, deterministic)
+}
+func (dst *BrokenLinkReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BrokenLinkReport.Merge(dst, src)
+}
+func (m *BrokenLinkReport) XXX_Size() int {
+	return xxx_messageInfo_BrokenLinkReport.Size(m)
+}
+func (m *BrokenLinkReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_BrokenLinkReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BrokenLinkReport proto.InternalMessageInfo
+
+func (m *BrokenLinkReport) GetLinks() []*BrokenLink {
+	if m != nil {
+		return m.Links
+	}
+	return nil
+}
+
+// A fragment that isn't on the page it's linked to.
+type BrokenAnchor struct {
+	// The page, without the fragment.
+	URL      string `protobuf:"bytes,1,opt,name=URL,proto3" json:"URL,omitempty"`
+	Fragment string `protobuf:"bytes,2,opt,name=fragment,proto3" json:"fragment,omitempty"`
+	// Every link to the fragment.
+	LinkedFrom           []*Link  `protobuf:"bytes,3,rep,name=linkedFrom,proto3" json:"linkedFrom,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BrokenAnchor) Reset()         { *m = BrokenAnchor{} }
+func (m *BrokenAnchor) String() string { return proto.CompactTextString(m) }
+func (*BrokenAnchor) ProtoMessage()    {}
+func (*BrokenAnchor) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{8}
+}
+func (m *BrokenAnchor) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BrokenAnchor.Unmarshal(m, b)
+}
+func (m *BrokenAnchor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BrokenAnchor.Marshal(b, m, deterministic)
+}
+func (dst *BrokenAnchor) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BrokenAnchor.Merge(dst, src)
+}
+func (m *BrokenAnchor) XXX_Size() int {
+	return xxx_messageInfo_BrokenAnchor.Size(m)
+}
+func (m *BrokenAnchor) XXX_DiscardUnknown() {
+	xxx_messageInfo_BrokenAnchor.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BrokenAnchor proto.InternalMessageInfo
+
+func (m *BrokenAnchor) GetURL() string {
+	if m != nil {
+		return m.URL
+	}
+	return ""
+}
+
+func (m *BrokenAnchor) GetFragment() string {
+	if m != nil {
+		return m.Fragment
+	}
+	return ""
+}
+
+func (m *BrokenAnchor) GetLinkedFrom() []*Link {
+	if m != nil {
+		return m.LinkedFrom
+	}
+	return nil
+}
+
+type BrokenAnchorReport struct {
+	Anchors              []*BrokenAnchor `protobuf:"bytes,1,rep,name=anchors,proto3" json:"anchors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *BrokenAnchorReport) Reset()         { *m = BrokenAnchorReport{} }
+func (m *BrokenAnchorReport) String() string { return proto.CompactTextString(m) }
+func (*BrokenAnchorReport) ProtoMessage()    {}
+func (*BrokenAnchorReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{9}
+}
+func (m *BrokenAnchorReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BrokenAnchorReport.Unmarshal(m, b)
+}
+func (m *BrokenAnchorReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BrokenAnchorReport.Marshal(b, m, deterministic)
+}
+func (dst *BrokenAnchorReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BrokenAnchorReport.Merge(dst, src)
+}
+func (m *BrokenAnchorReport) XXX_Size() int {
+	return xxx_messageInfo_BrokenAnchorReport.Size(m)
+}
+func (m *BrokenAnchorReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_BrokenAnchorReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BrokenAnchorReport proto.InternalMessageInfo
+
+func (m *BrokenAnchorReport) GetAnchors() []*BrokenAnchor {
+	if m != nil {
+		return m.Anchors
+	}
+	return nil
+}
+
+// A URL that went through more than one redirect, or whose
+// redirects never got anywhere.
+type RedirectChain struct {
+	URL  string      `protobuf:"bytes,1,opt,name=URL,proto3" json:"URL,omitempty"`
+	Hops []*Redirect `protobuf:"bytes,2,rep,name=hops,proto3" json:"hops,omitempty"`
+	// Where the chain ended up.
+	FinalURL  string             `protobuf:"bytes,3,opt,name=finalURL,proto3" json:"finalURL,omitempty"`
+	Outcome   SiteNode_Outcome   `protobuf:"varint,4,opt,name=outcome,proto3,enum=crawl.SiteNode_Outcome" json:"outcome,omitempty"`
+	Error     string             `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorKind SiteNode_ErrorKind `protobuf:"varint,6,opt,name=errorKind,proto3,enum=crawl.SiteNode_ErrorKind" json:"errorKind,omitempty"`
+	// Every link to the URL.
+	LinkedFrom           []*Link  `protobuf:"bytes,7,rep,name=linkedFrom,proto3" json:"linkedFrom,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RedirectChain) Reset()         { *m = RedirectChain{} }
+func (m *RedirectChain) String() string { return proto.CompactTextString(m) }
+func (*RedirectChain) ProtoMessage()    {}
+func (*RedirectChain) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{10}
+}
+func (m *RedirectChain) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RedirectChain.Unmarshal(m, b)
+}
+func (m *RedirectChain) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RedirectChain.Marshal(b, m, deterministic)
+}
+func (dst *RedirectChain) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RedirectChain.Merge(dst, src)
+}
+func (m *RedirectChain) XXX_Size() int {
+	return xxx_messageInfo_RedirectChain.Size(m)
+}
+func (m *RedirectChain) XXX_DiscardUnknown() {
+	xxx_messageInfo_RedirectChain.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RedirectChain proto.InternalMessageInfo
+
+func (m *RedirectChain) GetURL() string {
+	if m != nil {
+		return m.URL
+	}
+	return ""
+}
+
+func (m *RedirectChain) GetHops() []*Redirect {
+	if m != nil {
+		return m.Hops
+	}
+	return nil
+}
+
+func (m *RedirectChain) GetFinalURL() string {
+	if m != nil {
+		return m.FinalURL
+	}
+	return ""
+}
+
+func (m *RedirectChain) GetOutcome() SiteNode_Outcome {
+	if m != nil {
+		return m.Outcome
+	}
+	return SiteNode_PENDING
+}
+
+func (m *RedirectChain) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *RedirectChain) GetErrorKind() SiteNode_ErrorKind {
+	if m != nil {
+		return m.ErrorKind
+	}
+	return SiteNode_NO_ERROR
+}
+
+func (m *RedirectChain) GetLinkedFrom() []*Link {
+	if m != nil {
+		return m.LinkedFrom
+	}
+	return nil
+}
+
+type RedirectReport struct {
+	Chains               []*RedirectChain `protobuf:"bytes,1,rep,name=chains,proto3" json:"chains,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *RedirectReport) Reset()         { *m = RedirectReport{} }
+func (m *RedirectReport) String() string { return proto.CompactTextString(m) }
+func (*RedirectReport) ProtoMessage()    {}
+func (*RedirectReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{11}
+}
+func (m *RedirectReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RedirectReport.Unmarshal(m, b)
+}
+func (m *RedirectReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RedirectReport.Marshal(b, m, deterministic)
+}
+func (dst *RedirectReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RedirectReport.Merge(dst, src)
+}
+func (m *RedirectReport) XXX_Size() int {
+	return xxx_messageInfo_RedirectReport.Size(m)
+}
+func (m *RedirectReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_RedirectReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RedirectReport proto.InternalMessageInfo
+
+func (m *RedirectReport) GetChains() []*RedirectChain {
+	if m != nil {
+		return m.Chains
+	}
+	return nil
+}
+
+// A page, and the other URLs that are the same page.
+type DuplicateGroup struct {
+	URL                  string           `protobuf:"bytes,1,opt,name=URL,proto3" json:"URL,omitempty"`
+	Duplicates           []*DuplicatePage `protobuf:"bytes,2,rep,name=duplicates,proto3" json:"duplicates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *DuplicateGroup) Reset()         { *m = DuplicateGroup{} }
+func (m *DuplicateGroup) String() string { return proto.CompactTextString(m) }
+func (*DuplicateGroup) ProtoMessage()    {}
+func (*DuplicateGroup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{12}
+}
+func (m *DuplicateGroup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DuplicateGroup.Unmarshal(m, b)
+}
+func (m *DuplicateGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DuplicateGroup.Marshal(b, m, deterministic)
+}
+func (dst *DuplicateGroup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DuplicateGroup.Merge(dst, src)
+}
+func (m *DuplicateGroup) XXX_Size() int {
+	return xxx_messageInfo_DuplicateGroup.Size(m)
+}
+func (m *DuplicateGroup) XXX_DiscardUnknown() {
+	xxx_messageInfo_DuplicateGroup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DuplicateGroup proto.InternalMessageInfo
+
+func (m *DuplicateGroup) GetURL() string {
+	if m != nil {
+		return m.URL
+	}
+	return ""
+}
+
+func (m *DuplicateGroup) GetDuplicates() []*DuplicatePage {
+	if m != nil {
+		return m.Duplicates
+	}
+	return nil
+}
+
+type DuplicatePage struct {
+	URL string `protobuf:"bytes,1,opt,name=URL,proto3" json:"URL,omitempty"`
+	// "canonical" or "same content".
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DuplicatePage) Reset()         { *m = DuplicatePage{} }
+func (m *DuplicatePage) String() string { return proto.CompactTextString(m) }
+func (*DuplicatePage) ProtoMessage()    {}
+func (*DuplicatePage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{13}
+}
+func (m *DuplicatePage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DuplicatePage.Unmarshal(m, b)
+}
+func (m *DuplicatePage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DuplicatePage.Marshal(b, m, deterministic)
+}
+func (dst *DuplicatePage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DuplicatePage.Merge(dst, src)
+}
+func (m *DuplicatePage) XXX_Size() int {
+	return xxx_messageInfo_DuplicatePage.Size(m)
+}
+func (m *DuplicatePage) XXX_DiscardUnknown() {
+	xxx_messageInfo_DuplicatePage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DuplicatePage proto.InternalMessageInfo
+
+func (m *DuplicatePage) GetURL() string {
+	if m != nil {
+		return m.URL
+	}
+	return ""
+}
+
+func (m *DuplicatePage) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type DuplicateReport struct {
+	Groups               []*DuplicateGroup `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *DuplicateReport) Reset()         { *m = DuplicateReport{} }
+func (m *DuplicateReport) String() string { return proto.CompactTextString(m) }
+func (*DuplicateReport) ProtoMessage()    {}
+func (*DuplicateReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{14}
+}
+func (m *DuplicateReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DuplicateReport.Unmarshal(m, b)
+}
+func (m *DuplicateReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DuplicateReport.Marshal(b, m, deterministic)
+}
+func (dst *DuplicateReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DuplicateReport.Merge(dst, src)
+}
+func (m *DuplicateReport) XXX_Size() int {
+	return xxx_messageInfo_DuplicateReport.Size(m)
+}
+func (m *DuplicateReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_DuplicateReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DuplicateReport proto.InternalMessageInfo
+
+func (m *DuplicateReport) GetGroups() []*DuplicateGroup {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
+// A page an audit found problems with.
+type AuditedPage struct {
+	URL  string    `protobuf:"bytes,1,opt,name=URL,proto3" json:"URL,omitempty"`
+	Meta *PageMeta `protobuf:"bytes,2,opt,name=meta,proto3" json:"meta,omitempty"`
+	// "missing title", "duplicate title", "missing description",
+	// "multiple h1s", or "thin page".
+	Problems []string `protobuf:"bytes,3,rep,name=problems,proto3" json:"problems,omitempty"`
+	// For a duplicate title, the other pages with the same title.
+	SameTitle            []string `protobuf:"bytes,4,rep,name=sameTitle,proto3" json:"sameTitle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuditedPage) Reset()         { *m = AuditedPage{} }
+func (m *AuditedPage) String() string { return proto.CompactTextString(m) }
+func (*AuditedPage) ProtoMessage()    {}
+func (*AuditedPage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{15}
+}
+func (m *AuditedPage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditedPage.Unmarshal(m, b)
+}
+func (m *AuditedPage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditedPage.Marshal(b, m, deterministic)
+}
+func (dst *AuditedPage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditedPage.Merge(dst, src)
+}
+func (m *AuditedPage) XXX_Size() int {
+	return xxx_messageInfo_AuditedPage.Size(m)
+}
+func (m *AuditedPage) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditedPage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditedPage proto.InternalMessageInfo
+
+func (m *AuditedPage) GetURL() string {
+	if m != nil {
+		return m.URL
+	}
+	return ""
+}
+
+func (m *AuditedPage) GetMeta() *PageMeta {
+	if m != nil {
+		return m.Meta
+	}
+	return nil
+}
+
+func (m *AuditedPage) GetProblems() []string {
+	if m != nil {
+		return m.Problems
+	}
+	return nil
+}
+
+func (m *AuditedPage) GetSameTitle() []string {
+	if m != nil {
+		return m.SameTitle
+	}
+	return nil
+}
+
+type AuditReport struct {
+	Pages                []*AuditedPage `protobuf:"bytes,1,rep,name=pages,proto3" json:"pages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *AuditReport) Reset()         { *m = AuditReport{} }
+func (m *AuditReport) String() string { return proto.CompactTextString(m) }
+func (*AuditReport) ProtoMessage()    {}
+func (*AuditReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{16}
+}
+func (m *AuditReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditReport.Unmarshal(m, b)
+}
+func (m *AuditReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditReport.Marshal(b, m, deterministic)
+}
+func (dst *AuditReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditReport.Merge(dst, src)
+}
+func (m *AuditReport) XXX_Size() int {
+	return xxx_messageInfo_AuditReport.Size(m)
+}
+func (m *AuditReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditReport proto.InternalMessageInfo
+
+func (m *AuditReport) GetPages() []*AuditedPage {
+	if m != nil {
+		return m.Pages
+	}
+	return nil
+}
+
+// How quickly a crawl's pages came back, and how big they were.
+// Times are in milliseconds: the time before each response started
+// to arrive (first byte), and for the whole fetch.
+type CrawlPerf struct {
+	// Pages that were fetched and answered, successfully or not.
+	Pages              int32 `protobuf:"varint,1,opt,name=pages,proto3" json:"pages,omitempty"`
+	FirstByteP50Millis int64 `protobuf:"varint,2,opt,name=firstByteP50Millis,proto3" json:"firstByteP50Millis,omitempty"`
+	FirstByteP95Millis int64 `protobuf:"varint,3,opt,name=firstByteP95Millis,proto3" json:"firstByteP95Millis,omitempty"`
+	FirstByteMaxMillis int64 `protobuf:"varint,4,opt,name=firstByteMaxMillis,proto3" json:"firstByteMaxMillis,omitempty"`
+	FetchP50Millis     int64 `protobuf:"varint,5,opt,name=fetchP50Millis,proto3" json:"fetchP50Millis,omitempty"`
+	FetchP95Millis     int64 `protobuf:"varint,6,opt,name=fetchP95Millis,proto3" json:"fetchP95Millis,omitempty"`
+	FetchMaxMillis     int64 `protobuf:"varint,7,opt,name=fetchMaxMillis,proto3" json:"fetchMaxMillis,omitempty"`
+	// The size of all the pages put together.
+	TotalBytes           int64    `protobuf:"varint,8,opt,name=totalBytes,proto3" json:"totalBytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CrawlPerf) Reset()         { *m = CrawlPerf{} }
+func (m *CrawlPerf) String() string { return proto.CompactTextString(m) }
+func (*CrawlPerf) ProtoMessage()    {}
+func (*CrawlPerf) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{17}
+}
+func (m *CrawlPerf) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CrawlPerf.Unmarshal(m, b)
+}
+func (m *CrawlPerf) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CrawlPerf.Marshal(b, m, deterministic)
+}
+func (dst *CrawlPerf) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CrawlPerf.Merge(dst, src)
+}
+func (m *CrawlPerf) XXX_Size() int {
+	return xxx_messageInfo_CrawlPerf.Size(m)
+}
+func (m *CrawlPerf) XXX_DiscardUnknown() {
+	xxx_messageInfo_CrawlPerf.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CrawlPerf proto.InternalMessageInfo
+
+func (m *CrawlPerf) GetPages() int32 {
+	if m != nil {
+		return m.Pages
+	}
+	return 0
+}
+
+func (m *CrawlPerf) GetFirstByteP50Millis() int64 {
+	if m != nil {
+		return m.FirstByteP50Millis
+	}
+	return 0
+}
+
+func (m *CrawlPerf) GetFirstByteP95Millis() int64 {
+	if m != nil {
+		return m.FirstByteP95Millis
+	}
+	return 0
+}
+
+func (m *CrawlPerf) GetFirstByteMaxMillis() int64 {
+	if m != nil {
+		return m.FirstByteMaxMillis
+	}
+	return 0
+}
+
+func (m *CrawlPerf) GetFetchP50Millis() int64 {
+	if m != nil {
+		return m.FetchP50Millis
+	}
+	return 0
+}
+
+func (m *CrawlPerf) GetFetchP95Millis() int64 {
+	if m != nil {
+		return m.FetchP95Millis
+	}
+	return 0
+}
+
+func (m *CrawlPerf) GetFetchMaxMillis() int64 {
+	if m != nil {
+		return m.FetchMaxMillis
+	}
+	return 0
+}
+
+func (m *CrawlPerf) GetTotalBytes() int64 {
+	if m != nil {
+		return m.TotalBytes
+	}
+	return 0
+}
+
+// How long a page took to fetch, and how big it was.
+type PagePerf struct {
+	URL                  string   `protobuf:"bytes,1,opt,name=URL,proto3" json:"URL,omitempty"`
+	HttpStatus           int32    `protobuf:"varint,2,opt,name=httpStatus,proto3" json:"httpStatus,omitempty"`
+	FirstByteMillis      int64    `protobuf:"varint,3,opt,name=firstByteMillis,proto3" json:"firstByteMillis,omitempty"`
+	FetchMillis          int64    `protobuf:"varint,4,opt,name=fetchMillis,proto3" json:"fetchMillis,omitempty"`
+	Size                 int64    `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PagePerf) Reset()         { *m = PagePerf{} }
+func (m *PagePerf) String() string { return proto.CompactTextString(m) }
+func (*PagePerf) ProtoMessage()    {}
+func (*PagePerf) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{18}
+}
+func (m *PagePerf) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PagePerf.Unmarshal(m, b)
+}
+func (m *PagePerf) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PagePerf.Marshal(b, m, deterministic)
+}
+func (dst *PagePerf) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PagePerf.Merge(dst, src)
+}
+func (m *PagePerf) XXX_Size() int {
+	return xxx_messageInfo_PagePerf.Size(m)
+}
+func (m *PagePerf) XXX_DiscardUnknown() {
+	xxx_messageInfo_PagePerf.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PagePerf proto.InternalMessageInfo
+
+func (m *PagePerf) GetURL() string {
+	if m != nil {
+		return m.URL
+	}
+	return ""
+}
+
+func (m *PagePerf) GetHttpStatus() int32 {
+	if m != nil {
+		return m.HttpStatus
+	}
+	return 0
+}
+
+func (m *PagePerf) GetFirstByteMillis() int64 {
+	if m != nil {
+		return m.FirstByteMillis
+	}
+	return 0
+}
+
+func (m *PagePerf) GetFetchMillis() int64 {
+	if m != nil {
+		return m.FetchMillis
+	}
+	return 0
+}
+
+func (m *PagePerf) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type PerfReport struct {
+	Summary *CrawlPerf `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	// The pages that took longest to fetch, and the biggest, worst first.
+	Slowest              []*PagePerf `protobuf:"bytes,2,rep,name=slowest,proto3" json:"slowest,omitempty"`
+	Heaviest             []*PagePerf `protobuf:"bytes,3,rep,name=heaviest,proto3" json:"heaviest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *PerfReport) Reset()         { *m = PerfReport{} }
+func (m *PerfReport) String() string { return proto.CompactTextString(m) }
+func (*PerfReport) ProtoMessage()    {}
+func (*PerfReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{19}
+}
+func (m *PerfReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PerfReport.Unmarshal(m, b)
+}
+func (m *PerfReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PerfReport.Marshal(b, m, deterministic)
+}
+func (dst *PerfReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PerfReport.Merge(dst, src)
+}
+func (m *PerfReport) XXX_Size() int {
+	return xxx_messageInfo_PerfReport.Size(m)
+}
+func (m *PerfReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_PerfReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PerfReport proto.InternalMessageInfo
+
+func (m *PerfReport) GetSummary() *CrawlPerf {
+	if m != nil {
+		return m.Summary
+	}
+	return nil
+}
+
+func (m *PerfReport) GetSlowest() []*PagePerf {
+	if m != nil {
+		return m.Slowest
+	}
+	return nil
+}
+
+func (m *PerfReport) GetHeaviest() []*PagePerf {
+	if m != nil {
+		return m.Heaviest
+	}
+	return nil
+}
+
+// CrawlEvent is something that happened during a crawl,
+// sent by WatchCrawl as it happens.
+type CrawlEvent struct {
+	Kind CrawlEvent_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=crawl.CrawlEvent_Kind" json:"kind,omitempty"`
+	// When it happened, in milliseconds since the Unix epoch.
+	TimeMillis int64  `protobuf:"varint,2,opt,name=timeMillis,proto3" json:"timeMillis,omitempty"`
+	URL        string `protobuf:"bytes,3,opt,name=URL,proto3" json:"URL,omitempty"`
+	// For FETCHED, the HTTP status; zero if unknown.
+	HttpStatus int32 `protobuf:"varint,4,opt,name=httpStatus,proto3" json:"httpStatus,omitempty"`
+	// For FETCHED and FAILED, how long the fetch took.
+	ElapsedMillis int64  `protobuf:"varint,5,opt,name=elapsedMillis,proto3" json:"elapsedMillis,omitempty"`
+	Error         string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	// For STATE_CHANGED, the new state: running, stopped, done,
+	// or limit reached.
+	State                string   `protobuf:"bytes,7,opt,name=state,proto3" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CrawlEvent) Reset()         { *m = CrawlEvent{} }
+func (m *CrawlEvent) String() string { return proto.CompactTextString(m) }
+func (*CrawlEvent) ProtoMessage()    {}
+func (*CrawlEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{20}
+}
+func (m *CrawlEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CrawlEvent.Unmarshal(m, b)
+}
+func (m *CrawlEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CrawlEvent.Marshal(b, m, deterministic)
+}
+func (dst *CrawlEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CrawlEvent.Merge(dst, src)
+}
+func (m *CrawlEvent) XXX_Size() int {
+	return xxx_messageInfo_CrawlEvent.Size(m)
+}
+func (m *CrawlEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_CrawlEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CrawlEvent proto.InternalMessageInfo
+
+func (m *CrawlEvent) GetKind() CrawlEvent_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return CrawlEvent_QUEUED
+}
+
+func (m *CrawlEvent) GetTimeMillis() int64 {
+	if m != nil {
+		return m.TimeMillis
+	}
+	return 0
+}
+
+func (m *CrawlEvent) GetURL() string {
+	if m != nil {
+		return m.URL
+	}
+	return ""
+}
+
+func (m *CrawlEvent) GetHttpStatus() int32 {
+	if m != nil {
+		return m.HttpStatus
+	}
+	return 0
+}
+
+func (m *CrawlEvent) GetElapsedMillis() int64 {
+	if m != nil {
+		return m.ElapsedMillis
+	}
+	return 0
+}
+
+func (m *CrawlEvent) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *CrawlEvent) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+// ListRequest asks for a list of crawls. If states are given,
+// only crawls in those states are listed.
+type ListRequest struct {
+	States               []URLState_Status `protobuf:"varint,1,rep,packed,name=states,proto3,enum=crawl.URLState_Status" json:"states,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ListRequest) Reset()         { *m = ListRequest{} }
+func (m *ListRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRequest) ProtoMessage()    {}
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{21}
+}
+func (m *ListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
+}
+func (m *ListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRequest.Merge(dst, src)
+}
+func (m *ListRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRequest.Size(m)
+}
+func (m *ListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRequest proto.InternalMessageInfo
+
+func (m *ListRequest) GetStates() []URLState_Status {
+	if m != nil {
+		return m.States
+	}
+	return nil
+}
+
+// CrawlSummary is a crawl in a CrawlList.
+type CrawlSummary struct {
+	URL    string          `protobuf:"bytes,1,opt,name=URL,proto3" json:"URL,omitempty"`
+	Status URLState_Status `protobuf:"varint,2,opt,name=status,proto3,enum=crawl.URLState_Status" json:"status,omitempty"`
+	// When the crawl started, in milliseconds since the Unix
+	// epoch; zero if it hasn't.
+	StartedMillis int64 `protobuf:"varint,3,opt,name=startedMillis,proto3" json:"startedMillis,omitempty"`
+	Fetched       int32 `protobuf:"varint,4,opt,name=fetched,proto3" json:"fetched,omitempty"`
+	// URLs waiting to be crawled.
+	Queued int32 `protobuf:"varint,5,opt,name=queued,proto3" json:"queued,omitempty"`
+	// URLs that failed or were invalid.
+	Errors               int32    `protobuf:"varint,6,opt,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CrawlSummary) Reset()         { *m = CrawlSummary{} }
+func (m *CrawlSummary) String() string { return proto.CompactTextString(m) }
+func (*CrawlSummary) ProtoMessage()    {}
+func (*CrawlSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{22}
+}
+func (m *CrawlSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CrawlSummary.Unmarshal(m, b)
+}
+func (m *CrawlSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CrawlSummary.Marshal(b, m, deterministic)
+}
+func (dst *CrawlSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CrawlSummary.Merge(dst, src)
+}
+func (m *CrawlSummary) XXX_Size() int {
+	return xxx_messageInfo_CrawlSummary.Size(m)
+}
+func (m *CrawlSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_CrawlSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CrawlSummary proto.InternalMessageInfo
+
+func (m *CrawlSummary) GetURL() string {
+	if m != nil {
+		return m.URL
+	}
+	return ""
+}
+
+func (m *CrawlSummary) GetStatus() URLState_Status {
+	if m != nil {
+		return m.Status
+	}
+	return URLState_STOPPED
+}
+
+func (m *CrawlSummary) GetStartedMillis() int64 {
+	if m != nil {
+		return m.StartedMillis
+	}
+	return 0
+}
+
+func (m *CrawlSummary) GetFetched() int32 {
+	if m != nil {
+		return m.Fetched
+	}
+	return 0
+}
+
+func (m *CrawlSummary) GetQueued() int32 {
+	if m != nil {
+		return m.Queued
+	}
+	return 0
+}
+
+func (m *CrawlSummary) GetErrors() int32 {
+	if m != nil {
+		return m.Errors
+	}
+	return 0
+}
+
+// CrawlList is every crawl the server knows about.
+type CrawlList struct {
+	Crawls               []*CrawlSummary `protobuf:"bytes,1,rep,name=crawls,proto3" json:"crawls,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CrawlList) Reset()         { *m = CrawlList{} }
+func (m *CrawlList) String() string { return proto.CompactTextString(m) }
+func (*CrawlList) ProtoMessage()    {}
+func (*CrawlList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{23}
+}
+func (m *CrawlList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CrawlList.Unmarshal(m, b)
+}
+func (m *CrawlList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CrawlList.Marshal(b, m, deterministic)
+}
+func (dst *CrawlList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CrawlList.Merge(dst, src)
+}
+func (m *CrawlList) XXX_Size() int {
+	return xxx_messageInfo_CrawlList.Size(m)
+}
+func (m *CrawlList) XXX_DiscardUnknown() {
+	xxx_messageInfo_CrawlList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CrawlList proto.InternalMessageInfo
+
+func (m *CrawlList) GetCrawls() []*CrawlSummary {
+	if m != nil {
+		return m.Crawls
+	}
+	return nil
+}
+
+// DeleteRequest says which crawls to delete: the one for URL,
+// or if no URL is given, every crawl (all) or every crawl in
+// one of the given states.
+type DeleteRequest struct {
+	URL                  string            `protobuf:"bytes,1,opt,name=URL,proto3" json:"URL,omitempty"`
+	All                  bool              `protobuf:"varint,2,opt,name=all,proto3" json:"all,omitempty"`
+	States               []URLState_Status `protobuf:"varint,3,rep,packed,name=states,proto3,enum=crawl.URLState_Status" json:"states,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
+func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRequest) ProtoMessage()    {}
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{24}
+}
+func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
+}
+func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRequest.Merge(dst, src)
+}
+func (m *DeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteRequest.Size(m)
+}
+func (m *DeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
+
+func (m *DeleteRequest) GetURL() string {
+	if m != nil {
+		return m.URL
+	}
+	return ""
+}
+
+func (m *DeleteRequest) GetAll() bool {
+	if m != nil {
+		return m.All
+	}
+	return false
+}
+
+func (m *DeleteRequest) GetStates() []URLState_Status {
+	if m != nil {
+		return m.States
+	}
+	return nil
+}
+
+// DeleteResponse lists the crawls that were deleted.
+type DeleteResponse struct {
+	Deleted              []string `protobuf:"bytes,1,rep,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteResponse) Reset()         { *m = DeleteResponse{} }
+func (m *DeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteResponse) ProtoMessage()    {}
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_crawl_43381811b101b80f, []int{25}
+}
+func (m *DeleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteResponse.Unmarshal(m, b)
+}
+func (m *DeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteResponse.Marshal(b, m, deterministic)
+}
+func (dst *DeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteResponse.Merge(dst, src)
+}
+func (m *DeleteResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteResponse.Size(m)
+}
+func (m *DeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteResponse proto.InternalMessageInfo
+
+func (m *DeleteResponse) GetDeleted() []string {
+	if m != nil {
+		return m.Deleted
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*URLRequest)(nil), "crawl.URLRequest")
+	proto.RegisterType((*URLState)(nil), "crawl.URLState")
+	proto.RegisterType((*SiteNode)(nil), "crawl.SiteNode")
+	proto.RegisterType((*PageMeta)(nil), "crawl.PageMeta")
+	proto.RegisterType((*Redirect)(nil), "crawl.Redirect")
+	proto.RegisterType((*Link)(nil), "crawl.Link")
+	proto.RegisterType((*BrokenLink)(nil), "crawl.BrokenLink")
+	proto.RegisterType((*BrokenLinkReport)(nil), "crawl.BrokenLinkReport")
+	proto.RegisterType((*BrokenAnchor)(nil), "crawl.BrokenAnchor")
+	proto.RegisterType((*BrokenAnchorReport)(nil), "crawl.BrokenAnchorReport")
+	proto.RegisterType((*RedirectChain)(nil), "crawl.RedirectChain")
+	proto.RegisterType((*RedirectReport)(nil), "crawl.RedirectReport")
+	proto.RegisterType((*DuplicateGroup)(nil), "crawl.DuplicateGroup")
+	proto.RegisterType((*DuplicatePage)(nil), "crawl.DuplicatePage")
+	proto.RegisterType((*DuplicateReport)(nil), "crawl.DuplicateReport")
+	proto.RegisterType((*AuditedPage)(nil), "crawl.AuditedPage")
+	proto.RegisterType((*AuditReport)(nil), "crawl.AuditReport")
+	proto.RegisterType((*CrawlPerf)(nil), "crawl.CrawlPerf")
+	proto.RegisterType((*PagePerf)(nil), "crawl.PagePerf")
+	proto.RegisterType((*PerfReport)(nil), "crawl.PerfReport")
+	proto.RegisterType((*CrawlEvent)(nil), "crawl.CrawlEvent")
+	proto.RegisterType((*ListRequest)(nil), "crawl.ListRequest")
+	proto.RegisterType((*CrawlSummary)(nil), "crawl.CrawlSummary")
+	proto.RegisterType((*CrawlList)(nil), "crawl.CrawlList")
+	proto.RegisterType((*DeleteRequest)(nil), "crawl.DeleteRequest")
+	proto.RegisterType((*DeleteResponse)(nil), "crawl.DeleteResponse")
+	proto.RegisterEnum("crawl.URLRequestCommand", URLRequestCommand_name, URLRequestCommand_value)
+	proto.RegisterEnum("crawl.URLRequest_HostPolicy", URLRequest_HostPolicy_name, URLRequest_HostPolicy_value)
+	proto.RegisterEnum("crawl.URLRequest_NormalizeFlags", URLRequest_NormalizeFlags_name, URLRequest_NormalizeFlags_value)
+	proto.RegisterEnum("crawl.URLState_Status", URLState_Status_name, URLState_Status_value)
+	proto.RegisterEnum("crawl.SiteNode_Outcome", SiteNode_Outcome_name, SiteNode_Outcome_value)
+	proto.RegisterEnum("crawl.SiteNode_ErrorKind", SiteNode_ErrorKind_name, SiteNode_ErrorKind_value)
+	proto.RegisterEnum("crawl.CrawlEvent_Kind", CrawlEvent_Kind_name, CrawlEvent_Kind_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// CrawlClient is the client API for Crawl service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CrawlClient interface {
+	// Because we're calling the client from our CLI, we
+	// want the CrawlSite API to make a single request
+	// and wait for the response. This API lets us start,
+	// stop, or check the status of a URL
+	CrawlSite(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (*URLState, error)
+	// Checks the current status of a crawl and returns
+	// the tree as it stands, one SiteNode at a time: the root
+	// first, and then each node after its parent (depth first),
+	// with its level set so the client can put the tree back
+	// together. Sending a node at a time keeps a big crawl's tree
+	// under gRPC's message size limit.
+	CrawlResult(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (Crawl_CrawlResultClient, error)
+	// Sends the events of a crawl as they happen, starting with
+	// its current state. The stream ends when the crawl does.
+	WatchCrawl(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (Crawl_WatchCrawlClient, error)
+	// Lists the crawls the server knows about.
+	ListCrawls(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*CrawlList, error)
+	// Stops crawls and forgets them, including anything saved.
+	DeleteCrawl(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// Lists every URL in a crawl that couldn't be fetched, with
+	// every link to it.
+	BrokenLinks(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (*BrokenLinkReport, error)
+	// Lists every link in a crawl to a fragment that isn't on the
+	// page it points at.
+	BrokenAnchors(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (*BrokenAnchorReport, error)
+	// Lists every URL in a crawl whose redirects took more than one
+	// hop, or looped or went on too long.
+	RedirectChains(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (*RedirectReport, error)
+	// Lists the duplicate pages in a crawl, grouped under the page
+	// they duplicate.
+	Duplicates(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (*DuplicateReport, error)
+	// Lists the pages in a crawl with missing or duplicate titles,
+	// missing descriptions, more than one h1, or too few words.
+	Audit(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (*AuditReport, error)
+	// Summarizes how quickly a crawl's pages came back, and lists
+	// the slowest and heaviest of them.
+	Perf(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (*PerfReport, error)
+}
+
+type crawlClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCrawlClient(cc *grpc.ClientConn) CrawlClient {
+	return &crawlClient{cc}
+}
+
+func (c *crawlClient) CrawlSite(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (*URLState, error) {
+	out := new(URLState)
+	err := c.cc.Invoke(ctx, "/crawl.Crawl/CrawlSite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crawlClient) CrawlResult(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (Crawl_CrawlResultClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Crawl_serviceDesc.Streams[0], "/crawl.Crawl/CrawlResult", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &crawlCrawlResultClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Crawl_CrawlResultClient interface {
+	Recv() (*SiteNode, error)
+	grpc.ClientStream
+}
+
+type crawlCrawlResultClient struct {
+	grpc.ClientStream
+}
+
+func (x *crawlCrawlResultClient) Recv() (*SiteNode, error) {
+	m := new(SiteNode)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *crawlClient) WatchCrawl(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (Crawl_WatchCrawlClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Crawl_serviceDesc.Streams[1], "/crawl.Crawl/WatchCrawl", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &crawlWatchCrawlClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Crawl_WatchCrawlClient interface {
+	Recv() (*CrawlEvent, error)
+	grpc.ClientStream
+}
+
+type crawlWatchCrawlClient struct {
+	grpc.ClientStream
+}
+
+func (x *crawlWatchCrawlClient) Recv() (*CrawlEvent, error) {
+	m := new(CrawlEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *crawlClient) ListCrawls(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*CrawlList, error) {
+	out := new(CrawlList)
+	err := c.cc.Invoke(ctx, "/crawl.Crawl/ListCrawls", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crawlClient) DeleteCrawl(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, "/crawl.Crawl/DeleteCrawl", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crawlClient) BrokenLinks(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (*BrokenLinkReport, error) {
+	out := new(BrokenLinkReport)
+	err := c.cc.Invoke(ctx, "/crawl.Crawl/BrokenLinks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crawlClient) BrokenAnchors(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (*BrokenAnchorReport, error) {
+	out := new(BrokenAnchorReport)
+	err := c.cc.Invoke(ctx, "/crawl.Crawl/BrokenAnchors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crawlClient) RedirectChains(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (*RedirectReport, error) {
+	out := new(RedirectReport)
+	err := c.cc.Invoke(ctx, "/crawl.Crawl/RedirectChains", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crawlClient) Duplicates(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (*DuplicateReport, error) {
+	out := new(DuplicateReport)
+	err := c.cc.Invoke(ctx, "/crawl.Crawl/Duplicates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crawlClient) Audit(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (*AuditReport, error) {
+	out := new(AuditReport)
+	err := c.cc.Invoke(ctx, "/crawl.Crawl/Audit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crawlClient) Perf(ctx context.Context, in *URLRequest, opts ...grpc.CallOption) (*PerfReport, error) {
+	out := new(PerfReport)
+	err := c.cc.Invoke(ctx, "/crawl.Crawl/Perf", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CrawlServer is the server API for Crawl service.
+type CrawlServer interface {
+	// Because we're calling the client from our CLI, we
+	// want the CrawlSite API to make a single request
+	// and wait for the response. This API lets us start,
 	// stop, or check the status of a URL
 	CrawlSite(context.Context, *URLRequest) (*URLState, error)
 	// Checks the current status of a crawl and returns
-	// the tree as it stands.
+	// the tree as it stands, one SiteNode at a time: the root
+	// first, and then each node after its parent (depth first),
+	// with its level set so the client can put the tree back
+	// together. Sending a node at a time keeps a big crawl's tree
+	// under gRPC's message size limit.
 	CrawlResult(*URLRequest, Crawl_CrawlResultServer) error
+	// Sends the events of a crawl as they happen, starting with
+	// its current state. The stream ends when the crawl does.
+	WatchCrawl(*URLRequest, Crawl_WatchCrawlServer) error
+	// Lists the crawls the server knows about.
+	ListCrawls(context.Context, *ListRequest) (*CrawlList, error)
+	// Stops crawls and forgets them, including anything saved.
+	DeleteCrawl(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	// Lists every URL in a crawl that couldn't be fetched, with
+	// every link to it.
+	BrokenLinks(context.Context, *URLRequest) (*BrokenLinkReport, error)
+	// Lists every link in a crawl to a fragment that isn't on the
+	// page it points at.
+	BrokenAnchors(context.Context, *URLRequest) (*BrokenAnchorReport, error)
+	// Lists every URL in a crawl whose redirects took more than one
+	// hop, or looped or went on too long.
+	RedirectChains(context.Context, *URLRequest) (*RedirectReport, error)
+	// Lists the duplicate pages in a crawl, grouped under the page
+	// they duplicate.
+	Duplicates(context.Context, *URLRequest) (*DuplicateReport, error)
+	// Lists the pages in a crawl with missing or duplicate titles,
+	// missing descriptions, more than one h1, or too few words.
+	Audit(context.Context, *URLRequest) (*AuditReport, error)
+	// Summarizes how quickly a crawl's pages came back, and lists
+	// the slowest and heaviest of them.
+	Perf(context.Context, *URLRequest) (*PerfReport, error)
 }
 
 func RegisterCrawlServer(s *grpc.Server, srv CrawlServer) {
@@ -378,6 +2567,171 @@ func (x *crawlCrawlResultServer) Send(m *SiteNode) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Crawl_WatchCrawl_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(URLRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CrawlServer).WatchCrawl(m, &crawlWatchCrawlServer{stream})
+}
+
+type Crawl_WatchCrawlServer interface {
+	Send(*CrawlEvent) error
+	grpc.ServerStream
+}
+
+type crawlWatchCrawlServer struct {
+	grpc.ServerStream
+}
+
+func (x *crawlWatchCrawlServer) Send(m *CrawlEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Crawl_ListCrawls_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrawlServer).ListCrawls(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/crawl.Crawl/ListCrawls",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrawlServer).ListCrawls(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Crawl_DeleteCrawl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrawlServer).DeleteCrawl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/crawl.Crawl/DeleteCrawl",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrawlServer).DeleteCrawl(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Crawl_BrokenLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(URLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrawlServer).BrokenLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/crawl.Crawl/BrokenLinks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrawlServer).BrokenLinks(ctx, req.(*URLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Crawl_BrokenAnchors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(URLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrawlServer).BrokenAnchors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/crawl.Crawl/BrokenAnchors",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrawlServer).BrokenAnchors(ctx, req.(*URLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Crawl_RedirectChains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(URLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrawlServer).RedirectChains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/crawl.Crawl/RedirectChains",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrawlServer).RedirectChains(ctx, req.(*URLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Crawl_Duplicates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(URLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrawlServer).Duplicates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/crawl.Crawl/Duplicates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrawlServer).Duplicates(ctx, req.(*URLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Crawl_Audit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(URLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrawlServer).Audit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/crawl.Crawl/Audit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrawlServer).Audit(ctx, req.(*URLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Crawl_Perf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(URLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrawlServer).Perf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/crawl.Crawl/Perf",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrawlServer).Perf(ctx, req.(*URLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Crawl_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "crawl.Crawl",
 	HandlerType: (*CrawlServer)(nil),
@@ -386,6 +2740,38 @@ var _Crawl_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CrawlSite",
 			Handler:    _Crawl_CrawlSite_Handler,
 		},
+		{
+			MethodName: "ListCrawls",
+			Handler:    _Crawl_ListCrawls_Handler,
+		},
+		{
+			MethodName: "DeleteCrawl",
+			Handler:    _Crawl_DeleteCrawl_Handler,
+		},
+		{
+			MethodName: "BrokenLinks",
+			Handler:    _Crawl_BrokenLinks_Handler,
+		},
+		{
+			MethodName: "BrokenAnchors",
+			Handler:    _Crawl_BrokenAnchors_Handler,
+		},
+		{
+			MethodName: "RedirectChains",
+			Handler:    _Crawl_RedirectChains_Handler,
+		},
+		{
+			MethodName: "Duplicates",
+			Handler:    _Crawl_Duplicates_Handler,
+		},
+		{
+			MethodName: "Audit",
+			Handler:    _Crawl_Audit_Handler,
+		},
+		{
+			MethodName: "Perf",
+			Handler:    _Crawl_Perf_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -393,33 +2779,169 @@ var _Crawl_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Crawl_CrawlResult_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "WatchCrawl",
+			Handler:       _Crawl_WatchCrawl_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "crawl.proto",
 }
 
-func init() { proto.RegisterFile("crawl.proto", fileDescriptor_crawl_c886f2d72542114f) }
-
-var fileDescriptor_crawl_c886f2d72542114f = []byte{
-	// 321 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x51, 0xc1, 0x4e, 0x02, 0x31,
-	0x14, 0xdc, 0x82, 0x0b, 0xec, 0x23, 0xc1, 0xfa, 0x0e, 0x04, 0x3d, 0x18, 0xd2, 0x13, 0xa7, 0x45,
-	0x21, 0x7e, 0x80, 0x41, 0x22, 0x06, 0x2c, 0xa4, 0x65, 0xc3, 0xc5, 0xcb, 0x0a, 0x0d, 0x21, 0x01,
-	0x56, 0xb6, 0x25, 0xfe, 0x80, 0xfe, 0xb7, 0x69, 0x77, 0x71, 0x8d, 0x26, 0xde, 0x3a, 0xd3, 0x79,
-	0x33, 0x9d, 0x57, 0xa8, 0x2f, 0xd3, 0xf8, 0x7d, 0x1b, 0xbe, 0xa5, 0x89, 0x49, 0xd0, 0x77, 0x80,
-	0x7d, 0x10, 0x80, 0x48, 0x4c, 0x84, 0x3a, 0x1c, 0x95, 0x36, 0x48, 0xa1, 0x1c, 0x89, 0x49, 0x8b,
-	0xb4, 0x49, 0x27, 0x10, 0xf6, 0x88, 0x5d, 0xf0, 0xb5, 0x89, 0x8d, 0x6a, 0x95, 0xda, 0xa4, 0xd3,
-	0xe8, 0x5d, 0x86, 0x99, 0x49, 0x31, 0x13, 0x2e, 0x93, 0xdd, 0x2e, 0xde, 0xaf, 0x44, 0xa6, 0x63,
-	0x7d, 0xa8, 0xe6, 0x0c, 0x06, 0xe0, 0xcb, 0xf9, 0xbd, 0x98, 0x53, 0x0f, 0x6b, 0x70, 0x26, 0xe7,
-	0xd3, 0x19, 0x25, 0x96, 0x1c, 0x8c, 0x86, 0x83, 0x31, 0x2d, 0x39, 0x72, 0x34, 0x5d, 0xd0, 0x32,
-	0xfb, 0x24, 0x50, 0x8b, 0xc4, 0x44, 0x5a, 0x07, 0x0c, 0xa1, 0x62, 0xad, 0x8e, 0xda, 0xbd, 0xa3,
-	0xd1, 0x6b, 0x16, 0x99, 0x4e, 0x10, 0x4a, 0x77, 0x2b, 0x72, 0x15, 0xb6, 0xa0, 0xfa, 0xac, 0xb4,
-	0x8e, 0xd7, 0xd9, 0x23, 0x03, 0x71, 0x82, 0xac, 0x0b, 0x95, 0x4c, 0x8b, 0x75, 0xa8, 0xda, 0xfc,
-	0xd9, 0xf0, 0x81, 0x7a, 0x16, 0x88, 0x88, 0xf3, 0x27, 0xfe, 0x48, 0x89, 0x05, 0x11, 0x1f, 0xf3,
-	0xe9, 0x82, 0xd3, 0x12, 0x7b, 0x81, 0x9a, 0xdc, 0x18, 0xc5, 0x93, 0x95, 0xb2, 0xb6, 0x7a, 0x63,
-	0x54, 0xb1, 0x8f, 0x13, 0xc4, 0x6b, 0x00, 0x93, 0x2a, 0x25, 0x4d, 0xba, 0xd9, 0xaf, 0xf3, 0xcc,
-	0x1f, 0x0c, 0x36, 0xbf, 0x0b, 0x94, 0xdd, 0x5d, 0x8e, 0x7a, 0x07, 0xf0, 0x07, 0xb6, 0x09, 0xde,
-	0x42, 0xe0, 0x0e, 0x36, 0x0b, 0x2f, 0xfe, 0xac, 0xf4, 0xea, 0xfc, 0x57, 0x63, 0xe6, 0xe1, 0x1d,
-	0xd4, 0xdd, 0x88, 0x50, 0xfa, 0xb8, 0x35, 0xff, 0x0d, 0x9d, 0x0a, 0x30, 0xef, 0x86, 0xbc, 0x56,
-	0xdc, 0x6f, 0xf7, 0xbf, 0x02, 0x00, 0x00, 0xff, 0xff, 0x46, 0x41, 0x8d, 0x26, 0xfc, 0x01, 0x00,
-	0x00,
+func init() { proto.RegisterFile("crawl.proto", fileDescriptor_crawl_43381811b101b80f) }
+
+var fileDescriptor_crawl_43381811b101b80f = []byte{
+	// 2427 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x59, 0x4f, 0x73, 0xdb, 0xc6,
+	0x15, 0x17, 0x09, 0xfe, 0x7d, 0x94, 0x28, 0x68, 0x23, 0x2b, 0x88, 0x9a, 0xc9, 0x68, 0x10, 0x4f,
+	0xab, 0xe6, 0x8f, 0x6a, 0x2b, 0x71, 0x53, 0x4f, 0xdc, 0xb4, 0x34, 0x09, 0x59, 0xac, 0x29, 0x92,
+	0x59, 0x82, 0xb5, 0x73, 0xe8, 0x70, 0x60, 0x72, 0x25, 0x62, 0x04, 0x02, 0x0c, 0xb0, 0xb4, 0xad,
+	0x1c, 0xfa, 0x19, 0xda, 0x7b, 0x8e, 0x3d, 0xf4, 0x43, 0xf4, 0xd0, 0x43, 0x2f, 0xfd, 0x30, 0x9d,
+	0xe9, 0xa9, 0xb7, 0xce, 0x74, 0xf6, 0x61, 0x17, 0x04, 0x48, 0x5a, 0x75, 0x72, 0xd1, 0xe0, 0xfd,
+	0xd9, 0xdd, 0xb7, 0xbf, 0x7d, 0xfb, 0x7b, 0x6f, 0x29, 0xa8, 0x8d, 0x43, 0xe7, 0x95, 0x77, 0x32,
+	0x0f, 0x03, 0x1e, 0x90, 0x22, 0x0a, 0xe6, 0x7f, 0x2b, 0x00, 0x43, 0xda, 0xa1, 0xec, 0xdb, 0x05,
+	0x8b, 0x38, 0xd1, 0x41, 0x1b, 0xd2, 0x8e, 0x91, 0x3b, 0xca, 0x1d, 0x57, 0xa9, 0xf8, 0x24, 0xbf,
+	0x80, 0x62, 0xc4, 0x1d, 0xce, 0x8c, 0xfc, 0x51, 0xee, 0xb8, 0x7e, 0xfa, 0xde, 0x49, 0x3c, 0xc9,
+	0x72, 0xcc, 0xc9, 0x38, 0x98, 0xcd, 0x1c, 0x7f, 0x42, 0x63, 0x3f, 0x62, 0x40, 0xf9, 0x55, 0x10,
+	0x5e, 0xb3, 0x30, 0x32, 0xb4, 0xa3, 0xdc, 0x71, 0x91, 0x2a, 0x91, 0x7c, 0x02, 0x7b, 0x61, 0x3c,
+	0x26, 0xea, 0xb3, 0x70, 0xc0, 0xc6, 0x81, 0x3f, 0x31, 0x0a, 0x47, 0xb9, 0xe3, 0x1c, 0x5d, 0x37,
+	0x90, 0x7d, 0x28, 0xbe, 0x58, 0x84, 0x11, 0x37, 0x8a, 0x38, 0x4b, 0x2c, 0x90, 0x9f, 0x42, 0x7d,
+	0xe6, 0xfa, 0x2d, 0xe6, 0x39, 0x37, 0x17, 0xae, 0xe7, 0xb9, 0x91, 0x51, 0x3a, 0xca, 0x1d, 0x6b,
+	0x74, 0x45, 0x4b, 0x4e, 0x61, 0x7f, 0x6d, 0xca, 0x01, 0xe3, 0x86, 0x71, 0x94, 0x3b, 0xae, 0xd0,
+	0x8d, 0x36, 0x72, 0x08, 0x15, 0x5c, 0x44, 0xf8, 0xbd, 0x87, 0x7e, 0x89, 0x4c, 0x8e, 0xa0, 0xa6,
+	0x56, 0x10, 0xe6, 0x43, 0x34, 0xa7, 0x55, 0xc4, 0x84, 0x6d, 0xf7, 0xca, 0x0f, 0x42, 0x46, 0x83,
+	0x17, 0x01, 0x8f, 0x8c, 0x32, 0xba, 0x64, 0x74, 0x62, 0x85, 0xc8, 0xe5, 0x6c, 0xe6, 0xcc, 0x23,
+	0xa3, 0x12, 0xaf, 0xa0, 0x64, 0x61, 0x9b, 0x39, 0xaf, 0x5b, 0x6c, 0xce, 0xa7, 0x46, 0x15, 0xb7,
+	0x9c, 0xc8, 0xd2, 0xd6, 0x77, 0xae, 0x58, 0x64, 0x40, 0x62, 0x43, 0x59, 0x20, 0xc2, 0xdd, 0x19,
+	0x0b, 0x16, 0x3c, 0xde, 0x49, 0x64, 0xd4, 0x62, 0x44, 0xb2, 0x5a, 0x71, 0x2e, 0xae, 0x3f, 0xf6,
+	0x16, 0x13, 0x66, 0x6c, 0x1f, 0x69, 0xc7, 0x55, 0xaa, 0x44, 0x61, 0x61, 0xaf, 0x63, 0xcb, 0x4e,
+	0x6c, 0x91, 0x22, 0x79, 0x04, 0x30, 0x0d, 0x22, 0xde, 0x0f, 0x3c, 0x77, 0x7c, 0x63, 0xd4, 0x31,
+	0x03, 0xde, 0x5f, 0xcf, 0x80, 0xf3, 0xc4, 0x87, 0xa6, 0xfc, 0x05, 0x66, 0x42, 0x6a, 0x78, 0xae,
+	0x13, 0xb1, 0xc8, 0xd8, 0xc5, 0xb9, 0xd3, 0x2a, 0xf2, 0x3e, 0x54, 0x1d, 0xff, 0x66, 0x30, 0x9e,
+	0xb2, 0x19, 0x33, 0x74, 0x04, 0x64, 0xa9, 0x20, 0x07, 0x50, 0x72, 0xa2, 0x88, 0xf1, 0xc8, 0xd8,
+	0x43, 0x93, 0x94, 0xc8, 0x39, 0xd4, 0xfd, 0x20, 0x9c, 0x39, 0x9e, 0xfb, 0x1d, 0x3b, 0xf3, 0x9c,
+	0xab, 0xc8, 0x20, 0x18, 0xd9, 0xd1, 0x7a, 0x64, 0xdd, 0x8c, 0x1f, 0x5d, 0x19, 0x27, 0x22, 0x8c,
+	0x78, 0xe8, 0xce, 0xfb, 0x4e, 0xe8, 0xcc, 0x22, 0xe3, 0x9d, 0x38, 0xc2, 0x94, 0x4a, 0x44, 0x18,
+	0x05, 0x21, 0xff, 0x7a, 0xc1, 0xc2, 0x1b, 0x63, 0x3f, 0x8e, 0x30, 0x51, 0x08, 0xec, 0xbd, 0xe0,
+	0x15, 0x0b, 0xc7, 0x4e, 0xc4, 0xfa, 0x0e, 0x9f, 0x46, 0xc6, 0x1d, 0x74, 0x59, 0xd1, 0x8a, 0xcc,
+	0xbf, 0x66, 0x6c, 0x6e, 0x87, 0x8e, 0xeb, 0xb9, 0xfe, 0xd5, 0xc0, 0x73, 0xa2, 0xa9, 0x71, 0x80,
+	0xae, 0xeb, 0x06, 0x71, 0x09, 0x79, 0x30, 0x37, 0xde, 0xc5, 0x83, 0x16, 0x9f, 0xe6, 0x67, 0x50,
+	0x96, 0xb7, 0x8c, 0x54, 0xa1, 0x38, 0xb0, 0x1b, 0xd4, 0xd6, 0xb7, 0x48, 0x05, 0x0a, 0x03, 0xbb,
+	0xd7, 0xd7, 0x73, 0x42, 0xd9, 0x3c, 0xb7, 0x9a, 0x4f, 0xf5, 0x3c, 0x2a, 0xcf, 0x7b, 0xcf, 0x74,
+	0xcd, 0x6c, 0x01, 0x2c, 0x0f, 0x86, 0xd4, 0x01, 0xac, 0xe7, 0x8d, 0xa6, 0x3d, 0x3a, 0xef, 0x0d,
+	0xc4, 0xe0, 0x3a, 0xc0, 0x60, 0xf8, 0xb8, 0xd5, 0xbb, 0x68, 0xb4, 0xbb, 0x03, 0x3d, 0x47, 0x0e,
+	0x80, 0x50, 0xeb, 0x49, 0x7b, 0x60, 0xd3, 0xc6, 0xe3, 0x8e, 0x35, 0x8a, 0x0d, 0x7a, 0xde, 0xfc,
+	0x03, 0xd4, 0xb3, 0x20, 0x92, 0x3d, 0xd8, 0x69, 0x59, 0x67, 0x8d, 0x61, 0xc7, 0x1e, 0x9d, 0x75,
+	0x1a, 0x4f, 0x06, 0x72, 0xb2, 0xc6, 0x99, 0x25, 0x65, 0x9c, 0x6c, 0x38, 0x18, 0x36, 0x3a, 0x9d,
+	0x6f, 0x46, 0x29, 0x7d, 0x9e, 0xe8, 0xb0, 0x3d, 0xec, 0xa6, 0x34, 0x9a, 0xf9, 0xef, 0x1c, 0x54,
+	0x86, 0xb4, 0x33, 0x40, 0xea, 0x38, 0x81, 0x92, 0xe0, 0x90, 0x45, 0x84, 0x04, 0x54, 0x3f, 0x3d,
+	0x58, 0x1e, 0x28, 0x3a, 0x9c, 0x0c, 0xd0, 0x4a, 0xa5, 0x97, 0x48, 0xdc, 0x0b, 0x16, 0x45, 0xce,
+	0x55, 0xcc, 0x4e, 0x55, 0xaa, 0x44, 0x71, 0x61, 0x2e, 0xc3, 0xc0, 0xe7, 0x2e, 0x0b, 0x0d, 0x0d,
+	0x4f, 0x35, 0x91, 0xc9, 0x5d, 0x28, 0xcc, 0x59, 0x78, 0x89, 0xcc, 0x53, 0x3b, 0xd5, 0xe5, 0x1a,
+	0x4d, 0xf1, 0xb7, 0xcf, 0xc2, 0x4b, 0x8a, 0x56, 0xf3, 0x39, 0x94, 0xe2, 0xd5, 0x48, 0x0d, 0xca,
+	0x02, 0xe6, 0xbe, 0xd5, 0xd2, 0xb7, 0x84, 0x40, 0x87, 0xdd, 0x6e, 0xbb, 0xfb, 0x44, 0xcf, 0x09,
+	0x61, 0xd8, 0x7d, 0xda, 0xed, 0x3d, 0xeb, 0xc6, 0xc0, 0xb7, 0x7a, 0x5d, 0x4b, 0xd7, 0x08, 0x40,
+	0xe9, 0xac, 0xd1, 0xee, 0x58, 0x2d, 0xbd, 0x20, 0xc0, 0xea, 0xb4, 0x2f, 0xda, 0xf6, 0x88, 0x5a,
+	0x8d, 0xe6, 0xb9, 0xd5, 0xd2, 0x8b, 0xe6, 0xdf, 0xaa, 0x50, 0x19, 0xb8, 0x9c, 0x75, 0x83, 0xf8,
+	0xee, 0x09, 0x06, 0x58, 0x92, 0xae, 0x12, 0x45, 0xf6, 0x4b, 0x30, 0x34, 0x34, 0xa8, 0x4d, 0x1f,
+	0x40, 0x69, 0xee, 0x84, 0xcc, 0xe7, 0xb8, 0x81, 0x2a, 0x95, 0x92, 0xe0, 0xcb, 0x09, 0x92, 0x87,
+	0xe4, 0x4b, 0x14, 0xc8, 0x7d, 0x28, 0x07, 0x0b, 0x3e, 0x0e, 0x66, 0x0c, 0x89, 0xb2, 0x7e, 0xfa,
+	0xae, 0xdc, 0xaf, 0x8a, 0xe0, 0xa4, 0x17, 0x9b, 0xa9, 0xf2, 0x23, 0x1f, 0x00, 0x4c, 0x39, 0x9f,
+	0xc7, 0xbb, 0x47, 0x1a, 0x2b, 0xd2, 0x94, 0x46, 0x2c, 0xc4, 0xc2, 0x30, 0x08, 0x91, 0xc1, 0xaa,
+	0x34, 0x16, 0xd4, 0x46, 0x66, 0xce, 0x1c, 0xd9, 0xab, 0x42, 0x95, 0x28, 0xce, 0x22, 0x08, 0xe7,
+	0x53, 0xc7, 0x67, 0x13, 0x24, 0xaf, 0x0a, 0x4d, 0x64, 0xf2, 0x31, 0x54, 0xc6, 0x53, 0xd7, 0x9b,
+	0x84, 0xcc, 0x37, 0x6a, 0x47, 0xda, 0x71, 0xed, 0x74, 0x77, 0x25, 0x3e, 0x9a, 0x38, 0x90, 0x8f,
+	0x01, 0x3c, 0xd7, 0xbf, 0x66, 0x93, 0xb3, 0x30, 0x98, 0x21, 0x89, 0xd5, 0x4e, 0x6b, 0xd2, 0xbd,
+	0xe3, 0xfa, 0xd7, 0x34, 0x65, 0xc6, 0x0c, 0x70, 0x7d, 0xc7, 0x13, 0xc8, 0xee, 0x60, 0xa0, 0x89,
+	0x2c, 0xae, 0xfd, 0x38, 0xf0, 0x39, 0xf3, 0xb9, 0x7d, 0x33, 0x67, 0xc8, 0x6b, 0x55, 0x9a, 0x56,
+	0x11, 0x02, 0x85, 0xc8, 0xfd, 0x8e, 0x19, 0xbb, 0x48, 0xa5, 0xf8, 0x4d, 0xee, 0xc2, 0x8e, 0xe7,
+	0x70, 0xe6, 0x8f, 0x55, 0xe5, 0xd1, 0xd1, 0x98, 0x55, 0x92, 0x2f, 0xa0, 0x8a, 0x80, 0x3c, 0x75,
+	0xfd, 0x89, 0xb1, 0x97, 0xa9, 0x99, 0x09, 0xe4, 0x96, 0x72, 0xa0, 0x4b, 0x5f, 0x01, 0x2b, 0xf2,
+	0x1b, 0x92, 0x59, 0x95, 0xc6, 0x82, 0x80, 0xd5, 0xf1, 0xc7, 0xd3, 0x20, 0x54, 0xec, 0xa4, 0x44,
+	0xf2, 0x29, 0x54, 0x43, 0x36, 0x71, 0x43, 0x36, 0xe6, 0x91, 0xb1, 0x9f, 0xc1, 0x8e, 0x4a, 0x3d,
+	0x5d, 0x7a, 0x08, 0x22, 0x1b, 0x3b, 0x7e, 0xe0, 0xbb, 0x63, 0xc7, 0x43, 0x96, 0xaa, 0xd2, 0xa5,
+	0x22, 0x85, 0xc8, 0xb9, 0xa2, 0xa6, 0x2a, 0x4d, 0xab, 0x84, 0xc7, 0x64, 0x31, 0xf7, 0xdc, 0xb1,
+	0xc3, 0x59, 0xef, 0x12, 0xc9, 0xa9, 0x4a, 0xd3, 0x2a, 0x72, 0x0c, 0xbb, 0x89, 0x48, 0x99, 0x13,
+	0x05, 0x3e, 0x56, 0xdb, 0x2a, 0x5d, 0x55, 0x93, 0x0f, 0xa1, 0x30, 0x63, 0xdc, 0xc1, 0x22, 0xbb,
+	0x8c, 0x5a, 0x94, 0xb3, 0x0b, 0xc6, 0x1d, 0x8a, 0x46, 0x31, 0xdd, 0xa5, 0x1b, 0x46, 0xfc, 0xf1,
+	0x0d, 0x67, 0x12, 0xf0, 0x43, 0x04, 0x7c, 0x55, 0x2d, 0x90, 0xf3, 0xd8, 0x4b, 0xe6, 0x19, 0x3f,
+	0x89, 0x33, 0x1f, 0x05, 0xf3, 0x2f, 0x39, 0x28, 0xcb, 0xdc, 0x16, 0x17, 0xb5, 0x6f, 0x75, 0x5b,
+	0xe2, 0xd6, 0x6e, 0x91, 0x6d, 0xa8, 0x9c, 0x59, 0x76, 0xf3, 0x3c, 0xb9, 0xc3, 0x28, 0x59, 0x2d,
+	0x3d, 0x9f, 0xba, 0xb9, 0x9a, 0x30, 0xb4, 0xbb, 0xbf, 0x6f, 0x74, 0xda, 0xe2, 0x1a, 0xd7, 0xa0,
+	0xdc, 0x3b, 0x3b, 0x1b, 0xb4, 0x6d, 0x4b, 0x2f, 0x0a, 0xe1, 0x71, 0xa7, 0xd7, 0x7c, 0x6a, 0xb5,
+	0xf4, 0x12, 0xd9, 0x81, 0xea, 0xb0, 0xab, 0x66, 0x28, 0x0b, 0x86, 0xeb, 0x0d, 0xed, 0x51, 0xef,
+	0x6c, 0x34, 0x68, 0xf6, 0xfa, 0x96, 0x5e, 0x11, 0xdc, 0x48, 0xad, 0x56, 0x9b, 0x5a, 0x4d, 0xdb,
+	0x6a, 0xe9, 0x55, 0x31, 0xa0, 0x35, 0xec, 0x77, 0xda, 0xcd, 0x86, 0x6d, 0xe9, 0x60, 0xfe, 0x35,
+	0x07, 0xd5, 0x24, 0x1f, 0x44, 0x6c, 0xdd, 0xde, 0xc8, 0xa2, 0xb4, 0x47, 0xf5, 0x2d, 0xb2, 0x0b,
+	0xb5, 0x9e, 0x7d, 0x6e, 0x51, 0xa9, 0xc8, 0x89, 0xb9, 0xce, 0x6d, 0xbb, 0x2f, 0xe5, 0x3c, 0xce,
+	0xd5, 0x1d, 0x48, 0x51, 0x23, 0xfb, 0xa0, 0x37, 0x7b, 0xdd, 0xae, 0xd5, 0xb4, 0xdb, 0xbd, 0xae,
+	0xd4, 0x62, 0xec, 0x76, 0xfb, 0xc2, 0xea, 0x0d, 0x6d, 0xbd, 0x28, 0xa6, 0x94, 0xbb, 0x1a, 0x0d,
+	0x69, 0x47, 0x2f, 0x09, 0x82, 0x52, 0xe1, 0x8d, 0x3a, 0xbd, 0x5e, 0x5f, 0x2f, 0x0b, 0xf6, 0xb6,
+	0x7b, 0xbd, 0xd1, 0x45, 0xa3, 0xfb, 0xcd, 0x48, 0xd9, 0x06, 0x7a, 0xe5, 0x77, 0x85, 0x4a, 0x5e,
+	0xd7, 0xcc, 0x7f, 0xe4, 0xa0, 0xa2, 0x8e, 0x4a, 0x40, 0xcf, 0x5d, 0xee, 0x31, 0x49, 0x5e, 0xb1,
+	0x80, 0xb9, 0xc2, 0xa2, 0x71, 0xe8, 0xce, 0xb9, 0x1b, 0xf8, 0x92, 0x9b, 0xd3, 0x2a, 0x51, 0xe2,
+	0xa6, 0xf7, 0x23, 0x49, 0xcd, 0xe2, 0x53, 0xd0, 0x5a, 0x18, 0x37, 0x4e, 0x92, 0xd6, 0xc2, 0xa4,
+	0x65, 0xf2, 0x1c, 0xff, 0x6a, 0x21, 0x48, 0xbe, 0x18, 0xdf, 0x63, 0x25, 0x8b, 0xd5, 0x5f, 0x05,
+	0xe1, 0x24, 0xee, 0x01, 0x8b, 0x34, 0x16, 0xc4, 0x3d, 0x0d, 0x16, 0xfc, 0x45, 0xb0, 0xf0, 0x27,
+	0x82, 0x15, 0x14, 0x85, 0x65, 0x95, 0xe6, 0x73, 0xa8, 0xa8, 0x6b, 0xb2, 0xa1, 0xeb, 0xcd, 0x72,
+	0x60, 0x7e, 0x8d, 0x03, 0x45, 0x54, 0xc1, 0xd8, 0xc1, 0xed, 0x69, 0x32, 0x2a, 0x29, 0x9b, 0xa7,
+	0x50, 0x10, 0x4b, 0x20, 0x81, 0x07, 0x8b, 0x70, 0xac, 0xc0, 0x91, 0x92, 0xe0, 0x16, 0xce, 0x5e,
+	0x73, 0x09, 0x0b, 0x7e, 0x9b, 0xff, 0xca, 0x01, 0x3c, 0x0e, 0x83, 0x6b, 0xe6, 0xe3, 0xd0, 0xf5,
+	0x80, 0x52, 0x3c, 0x9e, 0xff, 0x51, 0x3c, 0xae, 0xbd, 0x99, 0xc7, 0x0b, 0x69, 0x1e, 0xcf, 0xf0,
+	0x57, 0xf1, 0x07, 0xf0, 0x57, 0x96, 0x9d, 0x4b, 0xb7, 0xb2, 0xb3, 0xf9, 0x25, 0xe8, 0xcb, 0xed,
+	0x52, 0x36, 0x0f, 0x42, 0x4e, 0x7e, 0x06, 0x45, 0x0f, 0xcf, 0x2b, 0x87, 0x63, 0xf7, 0xe4, 0xd8,
+	0x94, 0x5f, 0x6c, 0x37, 0x5d, 0xd8, 0x8e, 0x95, 0x0d, 0xa4, 0xc2, 0x0d, 0x68, 0x61, 0xf9, 0x77,
+	0xae, 0x66, 0xa2, 0x4a, 0xe6, 0x25, 0xf9, 0x4b, 0x79, 0x25, 0x4e, 0xed, 0xf6, 0x38, 0x9b, 0x40,
+	0xd2, 0x4b, 0xc9, 0x48, 0x3f, 0x5d, 0x92, 0x72, 0x1c, 0xeb, 0x3b, 0x99, 0x58, 0xa5, 0xaf, 0xf2,
+	0x31, 0xff, 0x9c, 0x87, 0x1d, 0x95, 0x6b, 0xcd, 0xa9, 0xe3, 0xfa, 0x1b, 0x22, 0xfe, 0x10, 0x0a,
+	0xd3, 0x60, 0x2e, 0x52, 0x6d, 0x23, 0x91, 0xa3, 0x31, 0x53, 0xd3, 0xb4, 0x95, 0x9a, 0x96, 0x4a,
+	0x90, 0xc2, 0x5b, 0x26, 0x48, 0x92, 0x00, 0xc5, 0x37, 0x26, 0x40, 0xe9, 0x47, 0x27, 0x40, 0xf9,
+	0x76, 0x60, 0xbf, 0x82, 0x7a, 0xb2, 0xb9, 0x18, 0xd4, 0x4f, 0xa0, 0x34, 0x16, 0xe0, 0x28, 0x4c,
+	0xf7, 0x57, 0x30, 0x40, 0xe4, 0xa8, 0xf4, 0x31, 0x9f, 0x43, 0xbd, 0xa5, 0xaa, 0xca, 0x93, 0x30,
+	0x58, 0xcc, 0x37, 0x60, 0xfa, 0x39, 0x40, 0x52, 0x79, 0x14, 0xb2, 0x6a, 0xd6, 0x64, 0xb0, 0xa0,
+	0x32, 0x9a, 0xf2, 0x33, 0x1f, 0xc2, 0x4e, 0xc6, 0xb8, 0x61, 0x62, 0xc1, 0x55, 0x71, 0x81, 0xcb,
+	0x4b, 0xae, 0x42, 0xc9, 0xfc, 0x2d, 0xec, 0xb6, 0x96, 0xa5, 0x4e, 0xa6, 0x4a, 0xe9, 0x4a, 0x84,
+	0xa7, 0x76, 0x75, 0x67, 0x75, 0x7d, 0x0c, 0x9e, 0x4a, 0x27, 0xf3, 0x8f, 0x50, 0x6b, 0x2c, 0x26,
+	0x2e, 0x67, 0x93, 0x37, 0x2c, 0xad, 0x4a, 0x67, 0xfe, 0xb6, 0xd2, 0x79, 0x08, 0x95, 0x79, 0x18,
+	0xbc, 0xf0, 0xd8, 0x4c, 0x51, 0x6c, 0x22, 0xe3, 0x83, 0xc6, 0x99, 0x31, 0x1b, 0x59, 0xbb, 0x80,
+	0xc6, 0xa5, 0xc2, 0xfc, 0x42, 0xae, 0x2f, 0xa3, 0x3f, 0x86, 0xe2, 0x1c, 0x1f, 0x9d, 0x71, 0xf0,
+	0x44, 0x2e, 0x97, 0x0a, 0x91, 0xc6, 0x0e, 0xe6, 0x3f, 0xf3, 0x50, 0x4d, 0x5a, 0x68, 0x91, 0x59,
+	0x6a, 0x1c, 0x12, 0x33, 0x0a, 0xe4, 0x04, 0x48, 0x52, 0xba, 0xfb, 0x0f, 0xee, 0xc9, 0xa2, 0x9e,
+	0xc7, 0xa2, 0xbe, 0xc1, 0x92, 0xf5, 0x7f, 0xf8, 0x40, 0xfa, 0x6b, 0xab, 0xfe, 0x0f, 0x1f, 0x6c,
+	0xf0, 0xbf, 0x70, 0x5e, 0x4b, 0xff, 0xc2, 0x8a, 0x7f, 0x62, 0x11, 0xaf, 0xb7, 0x4b, 0xc6, 0xc7,
+	0xd3, 0x65, 0x2c, 0xc5, 0xf8, 0xe5, 0x9c, 0xd5, 0x2e, 0xfd, 0x92, 0x18, 0x4a, 0x69, 0xbf, 0x87,
+	0x0f, 0x56, 0xfc, 0x96, 0x6b, 0x97, 0x53, 0x7e, 0xcb, 0x75, 0x3f, 0x00, 0xe0, 0x01, 0x77, 0x3c,
+	0x11, 0x4d, 0xfc, 0x3b, 0x80, 0x46, 0x53, 0x1a, 0xf3, 0x7b, 0x59, 0x61, 0x11, 0xca, 0x1f, 0x5e,
+	0x9b, 0x36, 0x34, 0x4e, 0xda, 0xe6, 0xc6, 0xe9, 0x08, 0x6a, 0x71, 0x68, 0x69, 0xa4, 0xd2, 0xaa,
+	0xa4, 0x0f, 0x2e, 0x2e, 0xfb, 0x60, 0xf3, 0x4f, 0x39, 0x00, 0x7c, 0x28, 0xc5, 0x39, 0xf2, 0x11,
+	0x94, 0xa3, 0xc5, 0x6c, 0xe6, 0x84, 0x37, 0x46, 0xee, 0x0d, 0x2f, 0x2a, 0xe5, 0x40, 0x7e, 0x0e,
+	0xe5, 0x48, 0x3c, 0x8d, 0x23, 0xbe, 0x42, 0x74, 0x6a, 0xbb, 0x54, 0xd9, 0xc5, 0xcb, 0x60, 0xca,
+	0x9c, 0x97, 0xae, 0xf0, 0xd5, 0x36, 0xfb, 0x26, 0x0e, 0xe6, 0xf7, 0x79, 0x00, 0x5c, 0xce, 0x7a,
+	0x29, 0x28, 0xfe, 0x23, 0x28, 0x5c, 0x0b, 0xf6, 0xca, 0xbe, 0x22, 0x97, 0x0e, 0x27, 0x48, 0x5d,
+	0xe8, 0x83, 0x87, 0xe1, 0xce, 0x58, 0x26, 0x19, 0x53, 0x1a, 0x85, 0xbf, 0xf6, 0x26, 0xfc, 0x0b,
+	0x6b, 0xf8, 0xdf, 0x85, 0x1d, 0xe6, 0x39, 0xf3, 0x88, 0x4d, 0x32, 0x59, 0x95, 0x55, 0x2e, 0xc9,
+	0xb7, 0x94, 0x26, 0xdf, 0x7d, 0xf5, 0x6b, 0x5b, 0x39, 0xd6, 0xa2, 0x60, 0x7e, 0x05, 0x05, 0x64,
+	0x58, 0x80, 0xd2, 0xd7, 0x43, 0x6b, 0xa8, 0x1e, 0xa2, 0xaa, 0xeb, 0xcc, 0xa5, 0xfa, 0xd6, 0xbc,
+	0x68, 0xe8, 0x06, 0x76, 0xc3, 0xb6, 0x46, 0xcd, 0xf3, 0x46, 0xf7, 0x89, 0x68, 0x65, 0xcd, 0x5f,
+	0x43, 0xad, 0xe3, 0x46, 0x5c, 0xfd, 0xc8, 0x27, 0x9f, 0xd9, 0xf2, 0x5a, 0xff, 0x9f, 0x67, 0x36,
+	0x8b, 0xcc, 0xbf, 0xe7, 0x60, 0x1b, 0xc1, 0x1b, 0xc8, 0x63, 0x5c, 0xcf, 0xc9, 0xe5, 0xcb, 0x3d,
+	0xff, 0x56, 0x2f, 0xf7, 0xbb, 0xb0, 0x13, 0x71, 0x27, 0xe4, 0x09, 0x46, 0x71, 0x86, 0x66, 0x95,
+	0xe2, 0xf1, 0x83, 0xc9, 0xc8, 0x26, 0x12, 0x66, 0x25, 0x0a, 0x06, 0xfe, 0x76, 0xc1, 0x16, 0x6c,
+	0x22, 0x5f, 0xbb, 0x52, 0x12, 0x7a, 0x04, 0x52, 0xb5, 0x84, 0x52, 0x32, 0x7f, 0x25, 0xd9, 0x49,
+	0xc0, 0x40, 0x3e, 0x86, 0x12, 0x46, 0xb7, 0x5a, 0xbd, 0xd3, 0x7b, 0xa4, 0xd2, 0xc5, 0x1c, 0xc3,
+	0x4e, 0x8b, 0x79, 0x8c, 0x33, 0x85, 0xde, 0xfa, 0xe6, 0x75, 0xd0, 0x1c, 0xcf, 0xc3, 0x9d, 0x57,
+	0xa8, 0xf8, 0x4c, 0x21, 0xac, 0xbd, 0x15, 0xc2, 0x1f, 0x41, 0x5d, 0x2d, 0x12, 0xcd, 0x03, 0x3f,
+	0xc2, 0xdf, 0x05, 0x26, 0xa8, 0x99, 0x60, 0x90, 0x55, 0xaa, 0xc4, 0xd3, 0xff, 0x14, 0xa0, 0x88,
+	0x91, 0x92, 0xfb, 0x72, 0x53, 0xa2, 0x2c, 0x93, 0xbd, 0xb5, 0x1f, 0xbf, 0x0e, 0x77, 0x57, 0x56,
+	0x35, 0xb7, 0xc8, 0x03, 0xa8, 0xe1, 0x10, 0xca, 0xa2, 0x85, 0xc7, 0x6f, 0x1b, 0xa4, 0x6a, 0xbd,
+	0xb9, 0x75, 0x2f, 0x47, 0x7e, 0x09, 0xf0, 0xcc, 0xe1, 0xe3, 0x69, 0xbc, 0xee, 0x86, 0x51, 0x7b,
+	0x6b, 0x77, 0x0c, 0xc7, 0x7d, 0x0e, 0x20, 0x10, 0x47, 0x6d, 0x44, 0x48, 0xd2, 0x0c, 0x24, 0xb9,
+	0x78, 0x98, 0x21, 0x0b, 0x61, 0x30, 0xb7, 0xc8, 0x23, 0xa8, 0xc5, 0x68, 0xc4, 0xcb, 0x25, 0x25,
+	0x3b, 0x7d, 0x0c, 0x87, 0x77, 0x56, 0xb4, 0x31, 0x6e, 0xe6, 0x16, 0xf9, 0x12, 0x6a, 0xcb, 0x96,
+	0x31, 0xda, 0x14, 0xec, 0xbb, 0xeb, 0x9d, 0x25, 0x52, 0x99, 0xb9, 0x45, 0x7e, 0x03, 0x3b, 0xe9,
+	0x1e, 0x6e, 0xe3, 0xf0, 0xf7, 0x36, 0x35, 0x7b, 0x6a, 0x82, 0x47, 0x50, 0xcf, 0x34, 0x2c, 0x1b,
+	0x67, 0xb8, 0xb3, 0xda, 0xde, 0xa9, 0xd1, 0x0f, 0x01, 0x92, 0xc6, 0x60, 0xe3, 0xc8, 0x83, 0xd5,
+	0xf6, 0x21, 0x19, 0x7a, 0x0f, 0x8a, 0x58, 0x96, 0x37, 0x8d, 0xca, 0xd4, 0xed, 0x64, 0xc4, 0x09,
+	0x14, 0xb0, 0xc2, 0xdc, 0x72, 0x9c, 0x4b, 0x9a, 0x37, 0xb7, 0x5e, 0x94, 0xf0, 0x1f, 0x07, 0x9f,
+	0xfd, 0x6f, 0x00, 0xb5, 0x81, 0x7c, 0xdb, 0x47, 0x18, 0x00, 0x00,
 }
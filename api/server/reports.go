@@ -0,0 +1,236 @@
+package Server
+
+import (
+	"context"
+	"time"
+
+	"github.com/joemcmahon/joe_macmahon_technical_test/api/crawl"
+	"github.com/joemcmahon/joe_macmahon_technical_test/crawler"
+)
+
+// Broken returns the URLs in a crawl that couldn't be fetched, with
+// every link to each of them.
+func (c *CrawlServer) Broken(url string) ([]crawler.BrokenLink, error) {
+	c.mutex.Lock()
+	defer (c.mutex.Unlock)()
+
+	state, err := c.crawled(url)
+	if err != nil {
+		return nil, err
+	}
+	return state.crawler.Broken(), nil
+}
+
+// BrokenLinks sends the broken link report for a crawl.
+func (c *CrawlServer) BrokenLinks(ctx context.Context, req *crawl.URLRequest) (*crawl.BrokenLinkReport, error) {
+	broken, err := c.Broken(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	report := &crawl.BrokenLinkReport{}
+	for _, b := range broken {
+		link := &crawl.BrokenLink{
+			URL:        b.URL,
+			Outcome:    outcomes[b.Outcome],
+			HttpStatus: int32(b.Status),
+			Error:      b.Error,
+			LinkedFrom: links(b.LinkedFrom),
+		}
+		if b.Error != "" {
+			link.ErrorKind = errorKinds[b.ErrorKind]
+		}
+		report.Links = append(report.Links, link)
+	}
+	return report, nil
+}
+
+// Anchors returns the broken anchors in the crawl of url.
+func (c *CrawlServer) Anchors(url string) ([]crawler.BrokenAnchor, error) {
+	c.mutex.Lock()
+	defer (c.mutex.Unlock)()
+
+	state, err := c.crawled(url)
+	if err != nil {
+		return nil, err
+	}
+	return state.crawler.BrokenAnchors(), nil
+}
+
+// BrokenAnchors sends every link in a crawl to a fragment that isn't
+// on its page.
+func (c *CrawlServer) BrokenAnchors(ctx context.Context, req *crawl.URLRequest) (*crawl.BrokenAnchorReport, error) {
+	broken, err := c.Anchors(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	report := &crawl.BrokenAnchorReport{}
+	for _, b := range broken {
+		report.Anchors = append(report.Anchors, &crawl.BrokenAnchor{
+			URL:        b.URL,
+			Fragment:   b.Fragment,
+			LinkedFrom: links(b.LinkedFrom),
+		})
+	}
+	return report, nil
+}
+
+// Redirects returns the redirect chains in the crawl of url.
+func (c *CrawlServer) Redirects(url string) ([]crawler.RedirectChain, error) {
+	c.mutex.Lock()
+	defer (c.mutex.Unlock)()
+
+	state, err := c.crawled(url)
+	if err != nil {
+		return nil, err
+	}
+	return state.crawler.RedirectChains(), nil
+}
+
+// RedirectChains sends every URL in a crawl whose redirects took more
+// than one hop, or never got anywhere.
+func (c *CrawlServer) RedirectChains(ctx context.Context, req *crawl.URLRequest) (*crawl.RedirectReport, error) {
+	chains, err := c.Redirects(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	report := &crawl.RedirectReport{}
+	for _, r := range chains {
+		chain := &crawl.RedirectChain{
+			URL:        r.URL,
+			Hops:       redirects(r.Hops),
+			FinalURL:   r.FinalURL,
+			Outcome:    outcomes[r.Outcome],
+			Error:      r.Error,
+			LinkedFrom: links(r.LinkedFrom),
+		}
+		if r.Error != "" {
+			chain.ErrorKind = errorKinds[r.ErrorKind]
+		}
+		report.Chains = append(report.Chains, chain)
+	}
+	return report, nil
+}
+
+// DuplicatePages returns the duplicate pages in the crawl of url.
+func (c *CrawlServer) DuplicatePages(url string) ([]crawler.DuplicateGroup, error) {
+	c.mutex.Lock()
+	defer (c.mutex.Unlock)()
+
+	state, err := c.crawled(url)
+	if err != nil {
+		return nil, err
+	}
+	return state.crawler.Duplicates(), nil
+}
+
+// Duplicates sends the duplicate pages in a crawl, grouped under the
+// page they duplicate.
+func (c *CrawlServer) Duplicates(ctx context.Context, req *crawl.URLRequest) (*crawl.DuplicateReport, error) {
+	groups, err := c.DuplicatePages(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	report := &crawl.DuplicateReport{}
+	for _, g := range groups {
+		group := &crawl.DuplicateGroup{URL: g.URL}
+		for _, dup := range g.Duplicates {
+			group.Duplicates = append(group.Duplicates, &crawl.DuplicatePage{URL: dup.URL, Reason: string(dup.Reason)})
+		}
+		report.Groups = append(report.Groups, group)
+	}
+	return report, nil
+}
+
+// AuditPages returns the pages with problems in the crawl of url.
+func (c *CrawlServer) AuditPages(url string) ([]crawler.AuditedPage, error) {
+	c.mutex.Lock()
+	defer (c.mutex.Unlock)()
+
+	state, err := c.crawled(url)
+	if err != nil {
+		return nil, err
+	}
+	return state.crawler.Audit(), nil
+}
+
+// Audit sends the pages in a crawl that have problems, with what
+// they say about themselves.
+func (c *CrawlServer) Audit(ctx context.Context, req *crawl.URLRequest) (*crawl.AuditReport, error) {
+	pages, err := c.AuditPages(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	report := &crawl.AuditReport{}
+	for _, p := range pages {
+		audited := &crawl.AuditedPage{
+			URL:       p.URL,
+			Meta:      pageMeta(p.Meta),
+			SameTitle: p.SameTitle,
+		}
+		for _, problem := range p.Problems {
+			audited.Problems = append(audited.Problems, string(problem))
+		}
+		report.Pages = append(report.Pages, audited)
+	}
+	return report, nil
+}
+
+// Performance measures the pages fetched so far in the crawl of url,
+// listing the top slowest and heaviest.
+func (c *CrawlServer) Performance(url string, top int) (crawler.Perf, error) {
+	c.mutex.Lock()
+	defer (c.mutex.Unlock)()
+
+	state, err := c.crawled(url)
+	if err != nil {
+		return crawler.Perf{}, err
+	}
+	if state.crawler == nil {
+		// Never actually crawled.
+		return crawler.Perf{}, nil
+	}
+	return state.crawler.Perf(top), nil
+}
+
+// Perf sends how quickly a crawl's pages came back, with the slowest
+// and heaviest pages.
+func (c *CrawlServer) Perf(ctx context.Context, req *crawl.URLRequest) (*crawl.PerfReport, error) {
+	perf, err := c.Performance(req.URL, int(req.Top))
+	if err != nil {
+		return nil, err
+	}
+	return &crawl.PerfReport{
+		Summary:  crawlPerf(perf),
+		Slowest:  pagePerfs(perf.Slowest),
+		Heaviest: pagePerfs(perf.Heaviest),
+	}, nil
+}
+
+// crawlPerf converts a crawl's timings and sizes for sending.
+func crawlPerf(p crawler.Perf) *crawl.CrawlPerf {
+	return &crawl.CrawlPerf{
+		Pages:              int32(p.Pages),
+		FirstByteP50Millis: int64(p.FirstByte.P50 / time.Millisecond),
+		FirstByteP95Millis: int64(p.FirstByte.P95 / time.Millisecond),
+		FirstByteMaxMillis: int64(p.FirstByte.Max / time.Millisecond),
+		FetchP50Millis:     int64(p.Fetch.P50 / time.Millisecond),
+		FetchP95Millis:     int64(p.Fetch.P95 / time.Millisecond),
+		FetchMaxMillis:     int64(p.Fetch.Max / time.Millisecond),
+		TotalBytes:         p.TotalBytes,
+	}
+}
+
+// pagePerfs converts a list of pages' timings and sizes for sending.
+func pagePerfs(pages []crawler.PagePerf) []*crawl.PagePerf {
+	var sent []*crawl.PagePerf
+	for _, p := range pages {
+		sent = append(sent, &crawl.PagePerf{
+			URL:             p.URL,
+			HttpStatus:      int32(p.Status),
+			FirstByteMillis: int64(p.FirstByte / time.Millisecond),
+			FetchMillis:     int64(p.Fetch / time.Millisecond),
+			Size:            int64(p.Size),
+		})
+	}
+	return sent
+}
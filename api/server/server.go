@@ -3,12 +3,17 @@ package Server
 import (
 	"context"
 	"fmt"
-	"strings"
+	neturl "net/url"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/joemcmahon/joe_macmahon_technical_test/api/crawl"
 	"github.com/joemcmahon/joe_macmahon_technical_test/crawler"
+	"github.com/joemcmahon/joe_macmahon_technical_test/crawler/page"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Define a dummy crawl server for the first iteration.
@@ -25,6 +30,7 @@ const (
 	done
 	unknown
 	failed
+	limited
 )
 
 // CrawlControl is the struct that minds a particular crawler.
@@ -35,34 +41,101 @@ type CrawlControl struct {
 
 // Fetcher defines an interface that can fetch URLs.
 type Fetcher interface {
-	// Fetch returns the body of URL and
-	// a slice of URLs found on that page.
-	Fetch(url string) (body string, urls []string, err error)
+	// Fetch fetches URL, and returns what happened: the page's
+	// body and links if it worked, and the error if it didn't.
+	Fetch(url string) *page.Result
+}
+
+// Config holds the server-wide settings.
+type Config struct {
+	// Defaults are the crawl options used for anything
+	// a request doesn't override.
+	Defaults crawler.Options
+	// DataDir is where crawls are saved, so they survive a restart.
+	// If empty, nothing is saved.
+	DataDir string
 }
 
 // CrawlServer defines the struct that holds the status of crawls
 type CrawlServer struct {
-	mutex sync.Mutex
-	f     Fetcher
+	mutex    sync.Mutex
+	f        Fetcher
+	defaults crawler.Options
+	// Shared by all crawls, so crawls of the same host share limits.
+	limiter *crawler.HostLimiter
 	// Crawler state for each URL
 	crawlers map[string]CrawlControl
+	store    store
 }
 
-// New creates and returns an empty CrawlServer.
-func New(f Fetcher) *CrawlServer {
-	return &CrawlServer{
+// New creates and returns a CrawlServer. If there are crawls saved in
+// the data directory, they're reloaded, and the ones that were running
+// carry on.
+func New(f Fetcher, config Config) *CrawlServer {
+	c := &CrawlServer{
 		f:        f,
+		defaults: config.Defaults,
+		limiter:  crawler.NewHostLimiter(),
 		crawlers: make(map[string]CrawlControl),
+		store:    store{dir: config.DataDir},
+	}
+	for _, saved := range c.store.load() {
+		saved.Crawl.Options.Limiter = c.limiter
+		restored, err := crawler.Restore(saved.Crawl, f)
+		if err != nil {
+			log.Errorf("can't restore crawl of %s: %s", saved.URL, err.Error())
+			continue
+		}
+		control := CrawlControl{
+			State:   saveableState(saved.State),
+			crawler: restored,
+		}
+		if control.State == running {
+			control.crawler.Start()
+		}
+		// A stopped crawl starts when it's resumed.
+		c.crawlers[saved.URL] = control
+		log.Infof("restored crawl of %s (%s)", saved.URL, saved.State)
+	}
+	return c
+}
+
+// Checkpoint saves every crawl to the data directory.
+func (c *CrawlServer) Checkpoint() {
+	c.mutex.Lock()
+	defer (c.mutex.Unlock)()
+	for url := range c.crawlers {
+		c.refresh(url)
+		c.checkpoint(url)
+	}
+}
+
+// checkpoint saves a crawl to the data directory, if there is one.
+// Must be called holding the mutex.
+func (c *CrawlServer) checkpoint(url string) {
+	control, ok := c.crawlers[url]
+	if !ok || control.crawler == nil || c.store.dir == "" {
+		return
+	}
+	saved := savedCrawl{
+		URL:   url,
+		State: translate(control.State),
+		Crawl: control.crawler.Snapshot(),
+	}
+	if err := c.store.save(saved); err != nil {
+		log.Errorf("can't save crawl of %s: %s", url, err.Error())
 	}
 }
 
-// Start starts a crawl for a URL.
-func (c *CrawlServer) Start(url string) (string, CrawlState, error) {
+// Start starts a crawl for a URL. The options are used if a new
+// crawl is created; resuming a stopped crawl keeps its original options.
+func (c *CrawlServer) Start(url string, opts crawler.Options) (string, CrawlState, error) {
 	var status string
 	var err error
 
 	c.mutex.Lock()
 	defer (c.mutex.Unlock)()
+	url = c.lookup(url, opts.Normalization)
 	log.Debug("selecting command")
 
 	var newState CrawlControl
@@ -72,9 +145,14 @@ func (c *CrawlServer) Start(url string) (string, CrawlState, error) {
 		switch state.State {
 		case running:
 			status = c.changeState(url, "running", "running", "no action")
-		case done:
-			status = c.changeState(url, "done", "running", "last crawl discarded, restarting crawl")
-			newState.crawler = crawler.New(url, c.f)
+		case done, limited:
+			status = c.changeState(url, translate(state.State), "running", "last crawl discarded, restarting crawl")
+			if old := state.crawler; old != nil {
+				// Closing waits for any pages still being fetched,
+				// so don't hold everyone else up while it does.
+				go old.Close()
+			}
+			newState.crawler = crawler.New(url, c.f, opts)
 			newState.crawler.Start()
 			newState.State = running
 		case stopped:
@@ -96,16 +174,29 @@ func (c *CrawlServer) Start(url string) (string, CrawlState, error) {
 	} else {
 		// Actually start a new crawl
 		log.Debug("Start crawl")
+		if err := crawlable(url); err != nil {
+			return "", unknown, err
+		}
 		status = c.changeState(url, translate(unknown), "running", "starting crawl")
-		newState.crawler = crawler.New(url, c.f)
+		newState.crawler = crawler.New(url, c.f, opts)
 		newState.crawler.Start()
 		newState.State = running
 	}
 	c.crawlers[url] = newState
-	log.Infof(status)
+	c.checkpoint(url)
+	log.Info(status)
 	return status, c.crawlers[url].State, err
 }
 
+// crawlable checks that a URL is one we can start a crawl at.
+func crawlable(url string) error {
+	u, err := neturl.Parse(url)
+	if err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+		return nil
+	}
+	return status.Errorf(codes.InvalidArgument, "can't crawl %q: not an http or https URL", url)
+}
+
 // Pause pauses a crawl for a URL.
 func (c *CrawlServer) Pause(url string) (string, CrawlState, error) {
 	var status string
@@ -114,6 +205,7 @@ func (c *CrawlServer) Pause(url string) (string, CrawlState, error) {
 	c.mutex.Lock()
 	defer (c.mutex.Unlock)()
 
+	url = c.lookup(url, c.defaults.Normalization)
 	if newState, ok := c.crawlers[url]; ok {
 		switch newState.State {
 		case running:
@@ -122,7 +214,7 @@ func (c *CrawlServer) Pause(url string) (string, CrawlState, error) {
 			if newState.crawler != nil {
 				newState.crawler.Pause()
 			}
-		case done, stopped, failed:
+		case done, stopped, failed, limited:
 			status = c.changeState(url, translate(newState.State), "stopped", "no action")
 		default:
 			// This would be an entry in state 'unknown', which should not be possible.
@@ -131,7 +223,8 @@ func (c *CrawlServer) Pause(url string) (string, CrawlState, error) {
 	} else {
 		status = c.changeState(url, translate(unknown), "stopped", "no action")
 	}
-	log.Infof(status)
+	c.checkpoint(url)
+	log.Info(status)
 	return status, c.crawlers[url].State, err
 }
 
@@ -140,38 +233,220 @@ func (c *CrawlServer) Probe(url string) string {
 	c.mutex.Lock()
 	defer (c.mutex.Unlock)()
 
+	url = c.lookup(url, c.defaults.Normalization)
+	c.refresh(url)
 	if crawlerState, ok := c.crawlers[url]; ok {
 		return translate(crawlerState.State)
 	}
 	return translate(unknown)
 }
 
-// Show translates the crawl tree into a string and returns it.
-// XXX: Note that this forces the output into a fixed format,
-//      but since this is for the CLI, we can live with it for now.
-//      Otherwise we need to extend the gotree interface and add
-//      a custom formatter for JSON or whatever. (YAGNI)
-func (c *CrawlServer) Show(url string) string {
+// Check reports the state of a crawl. If the crawl reached one of its
+// limits, the report says which, and lists the URLs left unfetched.
+func (c *CrawlServer) Check(url string) (string, CrawlState, []string) {
 	c.mutex.Lock()
 	defer (c.mutex.Unlock)()
 
-	var display string
-	if state, ok := c.crawlers[url]; ok {
-		switch state.State {
-		case running:
-			state.crawler.Lock()
-			display = state.crawler.Format()
-			state.crawler.Unlock()
-		case stopped, done:
-			display = state.crawler.Format()
-		case failed:
-			display = "Crawl failed; no valid results to show"
-		}
-	} else {
+	url = c.lookup(url, c.defaults.Normalization)
+	c.refresh(url)
+	control, ok := c.crawlers[url]
+	if !ok {
+		return translate(unknown), unknown, nil
+	}
+	if control.State != limited {
+		return translate(control.State), control.State, nil
+	}
+	_, limit := control.crawler.Status()
+	return fmt.Sprintf("%s: %s", translate(limited), limit), limited, control.crawler.Frontier()
+}
+
+// refresh catches up with a crawl that has finished on its own since
+// we last looked. Must be called holding the mutex.
+func (c *CrawlServer) refresh(url string) {
+	control, ok := c.crawlers[url]
+	if !ok || control.State != running || control.crawler == nil {
+		return
+	}
+	finished, limit := control.crawler.Status()
+	if !finished {
+		return
+	}
+	control.State = done
+	if limit != "" {
+		control.State = limited
+	}
+	c.crawlers[url] = control
+	c.checkpoint(url)
+}
+
+// Show returns the crawl tree for a URL as it stands. The tree is
+// rendered by the client, so that it can format it as it likes.
+func (c *CrawlServer) Show(url string) (*crawl.SiteNode, error) {
+	c.mutex.Lock()
+	defer (c.mutex.Unlock)()
+
+	url = c.lookup(url, c.defaults.Normalization)
+	state, err := c.crawled(url)
+	if err != nil {
+		return nil, err
+	}
+
+	root := siteNode(state.crawler.Results())
+	if root == nil {
+		// Nothing crawled yet; the root is all there is.
+		root = &crawl.SiteNode{SiteURL: url}
+	}
+	root.Status = translate(state.State)
+	return root, nil
+}
+
+// crawled finds the crawl for a URL that has results to show, or
+// returns the error to send if there isn't one. Must be called
+// holding the mutex.
+func (c *CrawlServer) crawled(url string) (CrawlControl, error) {
+	url = c.lookup(url, c.defaults.Normalization)
+	c.refresh(url)
+	state, ok := c.crawlers[url]
+	if !ok {
 		// Unknown, so we've done nothing with it.
-		return fmt.Sprintf("%s has not been crawled", url)
+		return state, status.Errorf(codes.NotFound, "%s has not been crawled", url)
+	}
+	if state.State == failed {
+		return state, status.Error(codes.FailedPrecondition, "Crawl failed; no valid results to show")
+	}
+	return state, nil
+}
+
+// lookup finds the key of the crawl for url. That's the crawl's root
+// URL as its normalization policy has it, so that any way of writing
+// the URL that normalizes to the same thing finds the same crawl. If
+// there's no crawl for url, it's the key a new crawl would have with
+// policy n. Must be called holding the mutex.
+func (c *CrawlServer) lookup(url string, n crawler.Normalization) string {
+	if _, ok := c.crawlers[url]; ok {
+		return url
+	}
+	for key, control := range c.crawlers {
+		if control.crawler == nil {
+			continue
+		}
+		if normal, err := control.crawler.Normalize(url); err == nil && normal == key {
+			return key
+		}
+	}
+	if normal, err := crawler.Normalize(url, n); err == nil {
+		return normal
+	}
+	return url
+}
+
+// siteNode converts a crawl result, and everything under it, for sending.
+func siteNode(r *crawler.Result) *crawl.SiteNode {
+	if r == nil {
+		return nil
+	}
+	n := &crawl.SiteNode{
+		SiteURL:         r.URL,
+		Parent:          r.Parent,
+		Depth:           int32(r.Depth),
+		Outcome:         outcomes[r.Outcome],
+		HttpStatus:      int32(r.Status),
+		Error:           r.Error,
+		Sitemap:         r.Sitemap,
+		Orphaned:        r.Orphaned,
+		FinalURL:        r.FinalURL,
+		ContentType:     r.ContentType,
+		Size:            int64(r.Size),
+		LatencyMillis:   int64(r.Latency / time.Millisecond),
+		FirstByteMillis: int64(r.FirstByte / time.Millisecond),
+		Asset:           string(r.Asset),
+		Anchors:         r.Anchors,
+		Redirects:       redirects(r.Redirects),
+		Canonical:       r.Canonical,
+		ContentHash:     r.Hash,
+		DuplicateOf:     r.DuplicateOf,
+		DuplicateReason: string(r.DuplicateReason),
+	}
+	if r.Error != "" {
+		n.ErrorKind = errorKinds[r.ErrorKind]
+	}
+	if r.Meta != nil {
+		n.Meta = pageMeta(*r.Meta)
+	}
+	n.LinkedFrom = links(r.LinkedFrom)
+	for _, child := range r.Children {
+		n.Children = append(n.Children, siteNode(child))
+	}
+	return n
+}
+
+// redirects converts a chain of redirects for sending.
+func redirects(hops []page.Redirect) []*crawl.Redirect {
+	var sent []*crawl.Redirect
+	for _, hop := range hops {
+		sent = append(sent, &crawl.Redirect{URL: hop.URL, HttpStatus: int32(hop.Status), Location: hop.Location})
 	}
-	return display
+	return sent
+}
+
+// pageMeta converts what a page says about itself for sending.
+func pageMeta(m page.Meta) *crawl.PageMeta {
+	return &crawl.PageMeta{
+		Title:         m.Title,
+		Description:   m.Description,
+		H1S:           m.H1s,
+		Robots:        m.Robots,
+		Language:      m.Language,
+		Words:         int32(m.Words),
+		OutboundLinks: int32(m.OutboundLinks),
+	}
+}
+
+// links converts the links to a page for sending.
+func links(from []page.Link) []*crawl.Link {
+	var sent []*crawl.Link
+	for _, link := range from {
+		sent = append(sent, &crawl.Link{Source: link.URL, Text: link.Text})
+	}
+	return sent
+}
+
+var outcomes = map[crawler.Outcome]crawl.SiteNode_Outcome{
+	crawler.Pending:    crawl.SiteNode_PENDING,
+	crawler.Fetching:   crawl.SiteNode_FETCHING,
+	crawler.Fetched:    crawl.SiteNode_FETCHED,
+	crawler.Failed:     crawl.SiteNode_FAILED,
+	crawler.Invalid:    crawl.SiteNode_INVALID,
+	crawler.Offsite:    crawl.SiteNode_OFFSITE,
+	crawler.Blocked:    crawl.SiteNode_BLOCKED,
+	crawler.Unfetched:  crawl.SiteNode_UNFETCHED,
+	crawler.OutOfScope: crawl.SiteNode_OUT_OF_SCOPE,
+	crawler.Redirected: crawl.SiteNode_REDIRECTED,
+	crawler.Duplicate:  crawl.SiteNode_DUPLICATE,
+}
+
+var hostPolicies = map[crawl.URLRequest_HostPolicy]crawler.HostPolicy{
+	crawl.URLRequest_EXACT_HOST:         crawler.ExactHost,
+	crawl.URLRequest_SUBDOMAINS:         crawler.Subdomains,
+	crawl.URLRequest_REGISTRABLE_DOMAIN: crawler.RegistrableDomain,
+}
+
+var flagSets = map[crawl.URLRequest_NormalizeFlags]crawler.FlagSet{
+	crawl.URLRequest_DEFAULT_FLAGS:      crawler.DefaultFlags,
+	crawl.URLRequest_SAFE_FLAGS:         crawler.SafeFlags,
+	crawl.URLRequest_USUALLY_SAFE_FLAGS: crawler.UsuallySafeFlags,
+	crawl.URLRequest_UNSAFE_FLAGS:       crawler.UnsafeFlags,
+}
+
+var errorKinds = map[page.ErrorKind]crawl.SiteNode_ErrorKind{
+	page.OtherError:       crawl.SiteNode_OTHER_ERROR,
+	page.HTTPError:        crawl.SiteNode_HTTP_ERROR,
+	page.DNSError:         crawl.SiteNode_DNS_ERROR,
+	page.ConnectionError:  crawl.SiteNode_CONNECTION_ERROR,
+	page.Timeout:          crawl.SiteNode_TIMEOUT,
+	page.InvalidURL:       crawl.SiteNode_INVALID_URL,
+	page.RedirectLoop:     crawl.SiteNode_REDIRECT_LOOP,
+	page.TooManyRedirects: crawl.SiteNode_TOO_MANY_REDIRECTS,
 }
 
 var xlate = map[CrawlState]string{
@@ -180,6 +455,7 @@ var xlate = map[CrawlState]string{
 	done:    "done",
 	unknown: "unknown",
 	failed:  "failed",
+	limited: "limit reached",
 }
 
 func translate(state CrawlState) string {
@@ -197,30 +473,193 @@ func (c *CrawlServer) changeState(url, old, new, result string) string {
 func (c *CrawlServer) CrawlSite(ctx context.Context, req *crawl.URLRequest) (*crawl.URLState, error) {
 	var status string
 	var state CrawlState
+	var frontier []string
 	var err error
 
 	switch req.State {
 	case crawl.URLRequest_START:
-		status, state, err = c.Start(req.URL)
+		var opts crawler.Options
+		opts, err = c.options(req)
+		if err != nil {
+			return nil, err
+		}
+		status, state, err = c.Start(req.URL, opts)
 
 	case crawl.URLRequest_STOP:
 		status, state, err = c.Pause(req.URL)
 
 	case crawl.URLRequest_CHECK:
-		status = c.Probe(req.URL)
+		status, state, frontier = c.Check(req.URL)
 	}
 
 	s := crawl.URLState{
-		Status:  sendableState(state),
-		Message: status,
+		Status:   sendableState(state),
+		Message:  status,
+		Frontier: frontier,
+	}
+	if req.State == crawl.URLRequest_CHECK {
+		if perf, perr := c.Performance(req.URL, 0); perr == nil && perf.Pages > 0 {
+			s.Perf = crawlPerf(perf)
+		}
 	}
 	return &s, err
 }
 
+// ListCrawls summarizes every crawl, or just the ones in the
+// requested states.
+func (c *CrawlServer) ListCrawls(ctx context.Context, req *crawl.ListRequest) (*crawl.CrawlList, error) {
+	c.mutex.Lock()
+	defer (c.mutex.Unlock)()
+
+	wanted := map[crawl.URLState_Status]bool{}
+	for _, s := range req.States {
+		wanted[s] = true
+	}
+	list := &crawl.CrawlList{}
+	for url := range c.crawlers {
+		c.refresh(url)
+		control := c.crawlers[url]
+		if control.crawler == nil {
+			// Never actually crawled.
+			continue
+		}
+		state := sendableState(control.State)
+		if len(wanted) > 0 && !wanted[state] {
+			continue
+		}
+		stats := control.crawler.Stats()
+		summary := &crawl.CrawlSummary{
+			URL:     url,
+			Status:  state,
+			Fetched: int32(stats.Fetched),
+			Queued:  int32(stats.Queued),
+			Errors:  int32(stats.Errors),
+		}
+		if !stats.Started.IsZero() {
+			summary.StartedMillis = millis(stats.Started)
+		}
+		list.Crawls = append(list.Crawls, summary)
+	}
+	sort.Slice(list.Crawls, func(i, j int) bool { return list.Crawls[i].URL < list.Crawls[j].URL })
+	return list, nil
+}
+
+// Delete stops the crawls for the given URLs and forgets them,
+// removing anything saved for them. It returns the URLs of the
+// crawls it deleted.
+func (c *CrawlServer) Delete(urls []string) []string {
+	c.mutex.Lock()
+	deleted := []string{}
+	closing := []*crawler.State{}
+	for _, url := range urls {
+		url = c.lookup(url, c.defaults.Normalization)
+		control, ok := c.crawlers[url]
+		if !ok {
+			continue
+		}
+		delete(c.crawlers, url)
+		if err := c.store.remove(url); err != nil {
+			log.Errorf("can't remove saved crawl of %s: %s", url, err.Error())
+		}
+		if control.crawler != nil {
+			closing = append(closing, control.crawler)
+		}
+		deleted = append(deleted, url)
+		log.Infof("deleted crawl of %s", url)
+	}
+	c.mutex.Unlock()
+
+	// Shutting a crawl down waits for pages being fetched, so
+	// don't hold everyone else up while we do it.
+	for _, cr := range closing {
+		cr.Close()
+	}
+	return deleted
+}
+
+// DeleteCrawl deletes the crawl for a URL, or all the crawls, or all
+// the crawls in the requested states.
+func (c *CrawlServer) DeleteCrawl(ctx context.Context, req *crawl.DeleteRequest) (*crawl.DeleteResponse, error) {
+	if req.URL != "" {
+		return &crawl.DeleteResponse{Deleted: c.Delete([]string{req.URL})}, nil
+	}
+	if !req.All && len(req.States) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "say which crawls to delete")
+	}
+
+	wanted := map[crawl.URLState_Status]bool{}
+	for _, s := range req.States {
+		wanted[s] = true
+	}
+	c.mutex.Lock()
+	urls := []string{}
+	for url := range c.crawlers {
+		c.refresh(url)
+		if req.All || wanted[sendableState(c.crawlers[url].State)] {
+			urls = append(urls, url)
+		}
+	}
+	c.mutex.Unlock()
+	sort.Strings(urls)
+	return &crawl.DeleteResponse{Deleted: c.Delete(urls)}, nil
+}
+
+// options builds the crawl options for a request, using the server
+// defaults for anything the request doesn't specify. It's an error
+// if the request's scope patterns don't compile.
+func (c *CrawlServer) options(req *crawl.URLRequest) (crawler.Options, error) {
+	opts := c.defaults
+	if req.Workers > 0 {
+		opts.Workers = int(req.Workers)
+	}
+	if req.RequestsPerSecond > 0 || req.RequestsPerSecondSet {
+		opts.Politeness.RequestsPerSecond = req.RequestsPerSecond
+	}
+	if req.Burst > 0 || req.BurstSet {
+		opts.Politeness.Burst = int(req.Burst)
+	}
+	if req.MinDelayMillis > 0 || req.MinDelaySet {
+		opts.Politeness.MinDelay = time.Duration(req.MinDelayMillis) * time.Millisecond
+	}
+	opts.IgnoreRobots = req.IgnoreRobots
+	opts.Sitemaps = req.Sitemaps
+	opts.Assets = req.Assets
+	if req.MaxDepth > 0 {
+		opts.Limits.MaxDepth = int(req.MaxDepth)
+	}
+	if req.MaxPages > 0 {
+		opts.Limits.MaxPages = int(req.MaxPages)
+	}
+	if req.TimeoutSeconds > 0 {
+		opts.Limits.Timeout = time.Duration(req.TimeoutSeconds) * time.Second
+	}
+	opts.Patterns = crawler.Patterns{Include: req.Include, Exclude: req.Exclude}
+	opts.Scope = crawler.Scope{
+		Hosts:     hostPolicies[req.HostPolicy],
+		Aliases:   req.HostAliases,
+		AnyScheme: req.AnyScheme,
+	}
+	opts.Normalization = crawler.Normalization{
+		Flags:             flagSets[req.NormalizeFlags],
+		StripParams:       req.StripParams,
+		SortQuery:         req.SortQuery,
+		LowercasePaths:    req.LowercasePaths,
+		KeepTrailingSlash: req.KeepTrailingSlash,
+	}
+	if err := opts.Patterns.Check(); err != nil {
+		return opts, status.Error(codes.InvalidArgument, err.Error())
+	}
+	opts.Limiter = c.limiter
+	return opts, nil
+}
+
 var sendable = map[CrawlState]crawl.URLState_Status{
 	stopped: crawl.URLState_STOPPED,
 	running: crawl.URLState_RUNNING,
 	unknown: crawl.URLState_UNKNOWN,
+	done:    crawl.URLState_DONE,
+	failed:  crawl.URLState_FAILED,
+	limited: crawl.URLState_LIMIT_REACHED,
 }
 
 func sendableState(state CrawlState) crawl.URLState_Status {
@@ -228,25 +667,41 @@ func sendableState(state CrawlState) crawl.URLState_Status {
 }
 
 var saveable = map[string]CrawlState{
-	"stopped": stopped,
-	"running": running,
-	"done":    done,
-	"unknown": unknown,
-	"failed":  failed,
+	"stopped":       stopped,
+	"running":       running,
+	"done":          done,
+	"unknown":       unknown,
+	"failed":        failed,
+	"limit reached": limited,
 }
 
 func saveableState(state string) CrawlState {
 	return saveable[state]
 }
 
-// CrawlResult sends the status of a given URL back over gRPC.
+// CrawlResult sends the crawl tree for a given URL back over gRPC,
+// a node at a time: the root first, then each node after its parent,
+// with its level in the tree. A big crawl's tree won't fit in one
+// message.
 func (c *CrawlServer) CrawlResult(req *crawl.URLRequest, stream crawl.Crawl_CrawlResultServer) error {
-	status := c.Probe(req.URL)
-	result := c.Show(req.URL)
-	for _, s := range strings.Split(result, "\n") {
-		n := crawl.SiteNode{SiteURL: req.URL, TreeString: s, Status: status}
-		if fail := stream.Send(&n); fail != nil {
-			return fail
+	root, err := c.Show(req.URL)
+	if err != nil {
+		return err
+	}
+	return sendTree(stream, root, 0)
+}
+
+// sendTree sends node and everything under it, depth first.
+func sendTree(stream crawl.Crawl_CrawlResultServer, node *crawl.SiteNode, level int) error {
+	children := node.Children
+	node.Children = nil
+	node.Level = int32(level)
+	if err := stream.Send(node); err != nil {
+		return err
+	}
+	for _, child := range children {
+		if err := sendTree(stream, child, level+1); err != nil {
+			return err
 		}
 	}
 	return nil
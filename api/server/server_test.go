@@ -1,20 +1,28 @@
 package Server
 
 import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/joemcmahon/joe_macmahon_technical_test/api/crawl"
+	"github.com/joemcmahon/joe_macmahon_technical_test/crawler"
 	"github.com/joemcmahon/joe_macmahon_technical_test/crawler/test/mock_fetcher"
 	"github.com/joemcmahon/logcap"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
 )
 
 const example = "https://www.example.com"
-const missing = "http://missing.org"
+const missing = "http://missing.org/"
 
 var _ = Describe("matches logs", func() {
 	f := MockFetcher.New()
-	s := New(f)
+	s := New(f, Config{})
 	Context("running", func() {
 		It("tries change running to stopped", func() {
 			s.crawlers[example] = CrawlControl{State: running}
@@ -29,7 +37,7 @@ var _ = Describe("matches logs", func() {
 			logHook := logcap.NewLogHook()
 			logHook.Start()
 			defer logHook.Stop()
-			s.Start(example)
+			s.Start(example, crawler.Options{})
 			Ω(logHook).Should(logcap.HaveLogs(s.changeState(example, "running", "running", "no action")))
 		})
 	})
@@ -47,7 +55,7 @@ var _ = Describe("matches logs", func() {
 			logHook := logcap.NewLogHook()
 			logHook.Start()
 			defer logHook.Stop()
-			s.Start(example)
+			s.Start(example, crawler.Options{})
 			Ω(logHook).Should(logcap.HaveLogs(s.changeState(example, "stopped", "running", "resuming crawl")))
 		})
 	})
@@ -67,7 +75,7 @@ var _ = Describe("matches logs", func() {
 			logHook := logcap.NewLogHook()
 			logHook.Start()
 			defer logHook.Stop()
-			s.Start(missing)
+			s.Start(missing, crawler.Options{})
 			Ω(logHook).Should(logcap.HaveLogs(s.changeState(missing, "unknown", "running", "starting crawl")))
 		})
 	})
@@ -85,7 +93,7 @@ var _ = Describe("matches logs", func() {
 			logHook := logcap.NewLogHook()
 			logHook.Start()
 			defer logHook.Stop()
-			s.Start(example)
+			s.Start(example, crawler.Options{})
 			Ω(logHook).Should(logcap.HaveLogs(s.changeState(example, "failed", "running", "retrying crawl")))
 		})
 	})
@@ -103,12 +111,571 @@ var _ = Describe("matches logs", func() {
 			logHook := logcap.NewLogHook()
 			logHook.Start()
 			defer logHook.Stop()
-			s.Start(example)
+			s.Start(example, crawler.Options{})
 			Ω(logHook).Should(logcap.HaveLogs(s.changeState(example, "done", "running", "last crawl discarded, restarting crawl")))
 		})
 	})
+	Context("limit reached", func() {
+		const golang = "http://golang.org/"
+		It("reports the limit and the unfetched URLs", func() {
+			s.Start(golang, crawler.Options{Limits: crawler.Limits{MaxPages: 1}})
+			s.crawlers[golang].crawler.Wait()
+			status, state, frontier := s.Check(golang)
+			Ω(state).Should(Equal(limited))
+			Ω(status).Should(Equal("limit reached: max pages (1)"))
+			Ω(frontier).Should(ContainElement("http://golang.org/pkg/"))
+		})
+		It("restarts the crawl on start", func() {
+			s.crawlers[example] = CrawlControl{State: limited}
+			logHook := logcap.NewLogHook()
+			logHook.Start()
+			defer logHook.Stop()
+			s.Start(example, crawler.Options{})
+			Ω(logHook).Should(logcap.HaveLogs(s.changeState(example, "limit reached", "running", "last crawl discarded, restarting crawl")))
+		})
+	})
+	Context("scoping crawls", func() {
+		It("refuses to start a crawl with a bad pattern", func() {
+			delete(s.crawlers, example)
+			_, err := s.CrawlSite(context.Background(), &crawl.URLRequest{
+				URL:     example,
+				State:   crawl.URLRequest_START,
+				Exclude: []string{"re:[z-a]"},
+			})
+			Ω(err).Should(MatchError(ContainSubstring("bad pattern")))
+			Ω(s.crawlers).ShouldNot(HaveKey(example))
+		})
+		It("returns the error when a crawl can't start", func() {
+			_, err := s.CrawlSite(context.Background(), &crawl.URLRequest{
+				URL:   "ftp://example.com/",
+				State: crawl.URLRequest_START,
+			})
+			Ω(err).Should(MatchError(ContainSubstring("not an http or https URL")))
+			Ω(s.crawlers).ShouldNot(HaveKey("ftp://example.com/"))
+		})
+		It("uses the politeness defaults unless they're set", func() {
+			p := New(f, Config{Defaults: crawler.Options{
+				Politeness: crawler.Politeness{RequestsPerSecond: 5, Burst: 5, MinDelay: time.Second},
+			}})
+			opts, err := p.options(&crawl.URLRequest{Burst: 2})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(opts.Politeness).Should(Equal(crawler.Politeness{RequestsPerSecond: 5, Burst: 2, MinDelay: time.Second}))
+			opts, err = p.options(&crawl.URLRequest{RequestsPerSecondSet: true, MinDelaySet: true})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(opts.Politeness).Should(Equal(crawler.Politeness{Burst: 5}))
+		})
+		It("passes the host policy on to the crawl", func() {
+			opts, err := s.options(&crawl.URLRequest{
+				HostPolicy:  crawl.URLRequest_SUBDOMAINS,
+				HostAliases: []string{"example.net"},
+				AnyScheme:   true,
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(opts.Scope).Should(Equal(crawler.Scope{
+				Hosts:     crawler.Subdomains,
+				Aliases:   []string{"example.net"},
+				AnyScheme: true,
+			}))
+		})
+	})
+	Context("finished on its own", func() {
+		const golang = "http://golang.org/"
+		It("is done", func() {
+			delete(s.crawlers, golang)
+			s.Start(golang, crawler.Options{})
+			s.crawlers[golang].crawler.Wait()
+			status, state, frontier := s.Check(golang)
+			Ω(state).Should(Equal(done))
+			Ω(status).Should(Equal("done"))
+			Ω(frontier).Should(BeEmpty())
+		})
+		It("shuts the old crawl down when it's restarted", func() {
+			old := s.crawlers[golang].crawler
+			Ω(old.Results()).ShouldNot(BeNil())
+			s.Start(golang, crawler.Options{})
+			Ω(s.crawlers[golang].crawler).ShouldNot(BeIdenticalTo(old))
+			Eventually(old.Results).Should(BeNil())
+			s.crawlers[golang].crawler.Wait()
+		})
+	})
+	Context("showing results", func() {
+		const golang = "http://golang.org/"
+		It("sends the tree as nodes", func() {
+			delete(s.crawlers, golang)
+			s.Start(golang, crawler.Options{})
+			s.crawlers[golang].crawler.Wait()
+			root, err := s.Show(golang)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(root.SiteURL).Should(Equal(golang))
+			Ω(root.Status).Should(Equal("done"))
+			Ω(root.Outcome).Should(Equal(crawl.SiteNode_FETCHED))
+			Ω(root.Children).ShouldNot(BeEmpty())
+			for _, child := range root.Children {
+				Ω(child.Parent).Should(Equal(golang))
+				Ω(child.Depth).Should(Equal(int32(1)))
+			}
+		})
+		It("sends what each fetch found", func() {
+			root, err := s.Show(golang)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(root.HttpStatus).Should(Equal(int32(200)))
+			Ω(root.FinalURL).Should(Equal(golang))
+			Ω(root.ErrorKind).Should(Equal(crawl.SiteNode_NO_ERROR))
+			var cmd *crawl.SiteNode
+			for _, child := range root.Children {
+				if child.SiteURL == "http://golang.org/cmd/" {
+					cmd = child
+				}
+			}
+			Ω(cmd).ShouldNot(BeNil())
+			Ω(cmd.Outcome).Should(Equal(crawl.SiteNode_FAILED))
+			Ω(cmd.HttpStatus).Should(Equal(int32(404)))
+			Ω(cmd.ErrorKind).Should(Equal(crawl.SiteNode_HTTP_ERROR))
+		})
+		It("streams the tree a node at a time", func() {
+			root, err := s.Show(golang)
+			Ω(err).ShouldNot(HaveOccurred())
+			var count func(*crawl.SiteNode) int
+			count = func(node *crawl.SiteNode) int {
+				n := 1
+				for _, child := range node.Children {
+					n += count(child)
+				}
+				return n
+			}
+
+			stream := &resultStream{}
+			Ω(s.CrawlResult(&crawl.URLRequest{URL: golang}, stream)).Should(Succeed())
+			Ω(stream.sent).Should(HaveLen(count(root)))
+			Ω(stream.sent[0].SiteURL).Should(Equal(golang))
+			Ω(stream.sent[0].Level).Should(BeZero())
+			Ω(stream.sent[0].Status).Should(Equal("done"))
+			parents := []*crawl.SiteNode{}
+			for _, node := range stream.sent {
+				Ω(node.Children).Should(BeEmpty())
+				level := int(node.Level)
+				Ω(level).Should(BeNumerically("<=", len(parents)), node.SiteURL)
+				if level > 0 {
+					Ω(node.Parent).Should(Equal(parents[level-1].SiteURL))
+				}
+				parents = append(parents[:level], node)
+			}
+		})
+		It("marks assets in asset mode", func() {
+			delete(s.crawlers, golang)
+			opts, err := s.options(&crawl.URLRequest{Assets: true})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(opts.Assets).Should(BeTrue())
+			s.Start(golang, opts)
+			s.crawlers[golang].crawler.Wait()
+			root, err := s.Show(golang)
+			Ω(err).ShouldNot(HaveOccurred())
+			kinds := map[string]string{}
+			for _, child := range root.Children {
+				kinds[child.SiteURL] = child.Asset
+			}
+			Ω(kinds).Should(HaveKeyWithValue("http://golang.org/lib/godoc/style.css", "stylesheet"))
+			Ω(kinds).Should(HaveKeyWithValue("http://golang.org/pkg/", ""))
+		})
+		It("says when there's nothing to show", func() {
+			delete(s.crawlers, missing)
+			_, err := s.Show(missing)
+			Ω(err).Should(MatchError(ContainSubstring("has not been crawled")))
+		})
+	})
+	Context("reporting broken links", func() {
+		const golang = "http://golang.org/"
+		It("sends each broken link with the links to it", func() {
+			delete(s.crawlers, golang)
+			s.Start(golang, crawler.Options{})
+			s.crawlers[golang].crawler.Wait()
+			report, err := s.BrokenLinks(context.Background(), &crawl.URLRequest{URL: golang})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(report.Links).Should(HaveLen(1))
+			broken := report.Links[0]
+			Ω(broken.URL).Should(Equal("http://golang.org/cmd/"))
+			Ω(broken.Outcome).Should(Equal(crawl.SiteNode_FAILED))
+			Ω(broken.HttpStatus).Should(Equal(int32(404)))
+			Ω(broken.ErrorKind).Should(Equal(crawl.SiteNode_HTTP_ERROR))
+			Ω(broken.LinkedFrom).Should(HaveLen(2))
+		})
+		It("says when there's nothing to report on", func() {
+			delete(s.crawlers, missing)
+			_, err := s.BrokenLinks(context.Background(), &crawl.URLRequest{URL: missing})
+			Ω(err).Should(MatchError(ContainSubstring("has not been crawled")))
+		})
+	})
+	Context("reporting broken anchors", func() {
+		const golang = "http://golang.org/"
+		It("sends each missing fragment with the links to it", func() {
+			delete(s.crawlers, golang)
+			s.Start(golang, crawler.Options{})
+			s.crawlers[golang].crawler.Wait()
+			report, err := s.BrokenAnchors(context.Background(), &crawl.URLRequest{URL: golang})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(report.Anchors).Should(HaveLen(1))
+			Ω(report.Anchors[0].URL).Should(Equal("http://golang.org/pkg/"))
+			Ω(report.Anchors[0].Fragment).Should(Equal("syscall"))
+			Ω(report.Anchors[0].LinkedFrom).Should(HaveLen(1))
+		})
+		It("says when there's nothing to report on", func() {
+			delete(s.crawlers, missing)
+			_, err := s.BrokenAnchors(context.Background(), &crawl.URLRequest{URL: missing})
+			Ω(err).Should(MatchError(ContainSubstring("has not been crawled")))
+		})
+	})
+	Context("reporting redirects", func() {
+		const blog = "http://golang.org/blog/"
+		It("sends each long chain with its hops", func() {
+			delete(s.crawlers, blog)
+			s.Start(blog, crawler.Options{})
+			s.crawlers[blog].crawler.Wait()
+			report, err := s.RedirectChains(context.Background(), &crawl.URLRequest{URL: blog})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(report.Chains).Should(HaveLen(2))
+			loop, moved := report.Chains[0], report.Chains[1]
+			Ω(loop.ErrorKind).Should(Equal(crawl.SiteNode_REDIRECT_LOOP))
+			Ω(moved.Hops).Should(HaveLen(2))
+			Ω(moved.Hops[0].HttpStatus).Should(Equal(int32(301)))
+			Ω(moved.FinalURL).Should(Equal(blog + "latest/"))
+		})
+		It("marks redirects off the site", func() {
+			root, err := s.Show(blog)
+			Ω(err).ShouldNot(HaveOccurred())
+			away := findNode(root, blog+"away/")
+			Ω(away).ShouldNot(BeNil())
+			Ω(away.Outcome).Should(Equal(crawl.SiteNode_REDIRECTED))
+			Ω(away.Redirects[0].Location).Should(Equal("http://blog.golang.org/"))
+		})
+	})
+	Context("reporting duplicates", func() {
+		const news = "http://golang.org/news/"
+		It("sends the duplicates grouped under their originals", func() {
+			delete(s.crawlers, news)
+			s.Start(news, crawler.Options{})
+			s.crawlers[news].crawler.Wait()
+			report, err := s.Duplicates(context.Background(), &crawl.URLRequest{URL: news})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(report.Groups).Should(HaveLen(2))
+			Ω(report.Groups[1].URL).Should(Equal(news + "item/1/"))
+			Ω(report.Groups[1].Duplicates).Should(HaveLen(2))
+			Ω(report.Groups[1].Duplicates[0].Reason).Should(Equal("canonical"))
+		})
+		It("marks duplicates in the tree", func() {
+			root, err := s.Show(news)
+			Ω(err).ShouldNot(HaveOccurred())
+			page1 := findNode(root, news+"?page=1")
+			Ω(page1.Outcome).Should(Equal(crawl.SiteNode_DUPLICATE))
+			Ω(page1.DuplicateOf).Should(Equal(news))
+			Ω(page1.DuplicateReason).Should(Equal("same content"))
+			Ω(page1.ContentHash).Should(Equal(root.ContentHash))
+		})
+	})
+	Context("auditing pages", func() {
+		const golang = "http://golang.org/"
+		It("sends the pages with problems", func() {
+			delete(s.crawlers, golang)
+			s.Start(golang, crawler.Options{Sitemaps: true})
+			s.crawlers[golang].crawler.Wait()
+			report, err := s.Audit(context.Background(), &crawl.URLRequest{URL: golang})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(report.Pages).Should(HaveLen(4))
+			pkg := report.Pages[1]
+			Ω(pkg.URL).Should(Equal("http://golang.org/pkg/"))
+			Ω(pkg.Problems).Should(Equal([]string{"duplicate title", "missing description", "multiple h1s", "thin page"}))
+			Ω(pkg.SameTitle).Should(Equal([]string{"http://golang.org/doc/"}))
+			Ω(pkg.Meta.H1S).Should(HaveLen(2))
+		})
+		It("sends what each page says about itself in the tree", func() {
+			root, err := s.Show(golang)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(root.Meta.Title).Should(Equal("The Go Programming Language"))
+			Ω(root.Meta.Language).Should(Equal("en"))
+		})
+		It("says when there's nothing to audit", func() {
+			delete(s.crawlers, missing)
+			_, err := s.Audit(context.Background(), &crawl.URLRequest{URL: missing})
+			Ω(err).Should(MatchError(ContainSubstring("has not been crawled")))
+		})
+	})
+	Context("measuring pages", func() {
+		const golang = "http://golang.org/"
+		It("sends the timings, with the slowest and heaviest pages", func() {
+			delete(s.crawlers, golang)
+			s.Start(golang, crawler.Options{})
+			s.crawlers[golang].crawler.Wait()
+			report, err := s.Perf(context.Background(), &crawl.URLRequest{URL: golang, Top: 1})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(report.Summary.Pages).Should(Equal(int32(5)))
+			Ω(report.Summary.FetchMaxMillis).Should(Equal(int64(900)))
+			Ω(report.Summary.FirstByteP50Millis).Should(Equal(int64(100)))
+			Ω(report.Slowest).Should(HaveLen(1))
+			Ω(report.Slowest[0].URL).Should(Equal("http://golang.org/pkg/"))
+			Ω(report.Heaviest[0].Size).Should(Equal(int64(250000)))
+		})
+		It("includes the timings in a check", func() {
+			state, err := s.CrawlSite(context.Background(), &crawl.URLRequest{URL: golang, State: crawl.URLRequest_CHECK})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(state.Status).Should(Equal(crawl.URLState_DONE))
+			Ω(state.Perf.Pages).Should(Equal(int32(5)))
+			Ω(state.Perf.FetchP95Millis).Should(Equal(int64(900)))
+			Ω(state.Perf.TotalBytes).ShouldNot(BeZero())
+		})
+		It("sends each page's time to first byte in the tree", func() {
+			root, err := s.Show(golang)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(root.FirstByteMillis).Should(Equal(int64(80)))
+		})
+		It("leaves the timings out of a check on a crawl with nothing to measure", func() {
+			s.crawlers[example] = CrawlControl{State: stopped}
+			state, err := s.CrawlSite(context.Background(), &crawl.URLRequest{URL: example, State: crawl.URLRequest_CHECK})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(state.Perf).Should(BeNil())
+		})
+	})
+	Context("normalizing URLs", func() {
+		const news = "http://golang.org/news/"
+		It("takes the normalization policy from the request", func() {
+			opts, err := s.options(&crawl.URLRequest{
+				URL:            news,
+				NormalizeFlags: crawl.URLRequest_SAFE_FLAGS,
+				StripParams:    []string{"utm_*"},
+				SortQuery:      true,
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(opts.Normalization.Flags).Should(Equal(crawler.SafeFlags))
+			Ω(opts.Normalization.StripParams).Should(Equal([]string{"utm_*"}))
+			Ω(opts.Normalization.SortQuery).Should(BeTrue())
+		})
+		It("finds a crawl however its URL is written", func() {
+			delete(s.crawlers, news)
+			opts := crawler.Options{Normalization: crawler.Normalization{StripParams: []string{"ref"}}}
+			s.Start("HTTP://golang.org/news?ref=home", opts)
+			Ω(s.crawlers).Should(HaveKey(news))
+			s.crawlers[news].crawler.Wait()
+			Ω(s.Probe("http://golang.org/news?ref=mail")).Should(Equal("done"))
+			root, err := s.Show("http://golang.org/news")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(root.SiteURL).Should(Equal(news))
+		})
+	})
+	Context("saving crawls", func() {
+		const golang = "http://golang.org/"
+		It("reloads them on startup", func() {
+			dir, err := ioutil.TempDir("", "crawl-data")
+			Ω(err).ShouldNot(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			first := New(f, Config{DataDir: dir})
+			first.Start(golang, crawler.Options{})
+			first.crawlers[golang].crawler.Wait()
+			first.Checkpoint()
+			want, err := first.Show(golang)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			second := New(f, Config{DataDir: dir})
+			Ω(second.Probe(golang)).Should(Equal("done"))
+			got, err := second.Show(golang)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(got).Should(Equal(want))
+		})
+		It("saves crawls of long URLs", func() {
+			dir, err := ioutil.TempDir("", "crawl-data")
+			Ω(err).ShouldNot(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			long := golang + "?q=" + strings.Repeat("gopher", 60)
+			first := New(f, Config{DataDir: dir})
+			first.Start(long, crawler.Options{})
+			first.crawlers[long].crawler.Wait()
+			first.Checkpoint()
+			Ω(first.store.path(long)).Should(BeAnExistingFile())
+
+			second := New(f, Config{DataDir: dir})
+			Ω(second.Probe(long)).Should(Equal("done"))
+		})
+		It("carries on with a stopped crawl when it's started", func() {
+			dir, err := ioutil.TempDir("", "crawl-data")
+			Ω(err).ShouldNot(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			first := New(f, Config{DataDir: dir})
+			first.Start(golang, crawler.Options{})
+			first.Pause(golang)
+			first.crawlers[golang].crawler.Quit()
+
+			second := New(f, Config{DataDir: dir})
+			Ω(second.Probe(golang)).Should(Equal("stopped"))
+			second.Start(golang, crawler.Options{})
+			second.crawlers[golang].crawler.Wait()
+			Ω(second.Probe(golang)).Should(Equal("done"))
+		})
+	})
+	Context("listing crawls", func() {
+		const golang = "http://golang.org/"
+		It("summarizes every crawl", func() {
+			delete(s.crawlers, golang)
+			s.Start(golang, crawler.Options{})
+			s.crawlers[golang].crawler.Wait()
+			list, err := s.ListCrawls(context.Background(), &crawl.ListRequest{})
+			Ω(err).ShouldNot(HaveOccurred())
+			var found *crawl.CrawlSummary
+			for _, summary := range list.Crawls {
+				if summary.URL == golang {
+					found = summary
+				}
+			}
+			Ω(found).ShouldNot(BeNil())
+			Ω(found.Status).Should(Equal(crawl.URLState_DONE))
+			Ω(found.Fetched).Should(Equal(int32(5)))
+			Ω(found.Errors).Should(Equal(int32(1)))
+			Ω(found.StartedMillis).ShouldNot(BeZero())
+		})
+		It("filters by state", func() {
+			delete(s.crawlers, golang)
+			s.Start(golang, crawler.Options{})
+			s.crawlers[golang].crawler.Wait()
+			list, err := s.ListCrawls(context.Background(), &crawl.ListRequest{
+				States: []crawl.URLState_Status{crawl.URLState_STOPPED, crawl.URLState_RUNNING},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			for _, summary := range list.Crawls {
+				Ω(summary.URL).ShouldNot(Equal(golang))
+				Ω([]crawl.URLState_Status{crawl.URLState_STOPPED, crawl.URLState_RUNNING}).Should(ContainElement(summary.Status))
+			}
+		})
+	})
+	Context("deleting crawls", func() {
+		const golang = "http://golang.org/"
+		It("forgets the crawl and what was saved", func() {
+			dir, err := ioutil.TempDir("", "crawl-data")
+			Ω(err).ShouldNot(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			d := New(f, Config{DataDir: dir})
+			d.Start(golang, crawler.Options{})
+			d.crawlers[golang].crawler.Wait()
+			d.Checkpoint()
+			Ω(d.store.path(golang)).Should(BeAnExistingFile())
+
+			resp, err := d.DeleteCrawl(context.Background(), &crawl.DeleteRequest{URL: golang})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(resp.Deleted).Should(Equal([]string{golang}))
+			Ω(d.crawlers).ShouldNot(HaveKey(golang))
+			Ω(d.store.path(golang)).ShouldNot(BeAnExistingFile())
+			Ω(d.Probe(golang)).Should(Equal("unknown"))
+		})
+		It("deletes crawls by state", func() {
+			d := New(f, Config{})
+			d.Start(golang, crawler.Options{})
+			d.crawlers[golang].crawler.Wait()
+			d.crawlers[example] = CrawlControl{State: stopped}
+
+			resp, err := d.DeleteCrawl(context.Background(), &crawl.DeleteRequest{
+				States: []crawl.URLState_Status{crawl.URLState_DONE},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(resp.Deleted).Should(Equal([]string{golang}))
+			Ω(d.crawlers).Should(HaveKey(example))
+
+			resp, err = d.DeleteCrawl(context.Background(), &crawl.DeleteRequest{All: true})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(resp.Deleted).Should(Equal([]string{example}))
+			Ω(d.crawlers).Should(BeEmpty())
+		})
+		It("stops a running crawl", func() {
+			d := New(f, Config{})
+			d.Start(golang, crawler.Options{Politeness: crawler.Politeness{MinDelay: time.Second}})
+			cr := d.crawlers[golang].crawler
+			d.Delete([]string{golang})
+			done, _ := cr.Status()
+			Ω(done).Should(BeTrue())
+			Ω(cr.Results()).Should(BeNil())
+		})
+		It("needs to be told what to delete", func() {
+			_, err := s.DeleteCrawl(context.Background(), &crawl.DeleteRequest{})
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+	Context("watching a crawl", func() {
+		const golang = "http://golang.org/"
+		It("streams events until the crawl is done", func() {
+			delete(s.crawlers, golang)
+			s.Start(golang, crawler.Options{})
+			stream := &watchStream{ctx: context.Background()}
+			Ω(s.WatchCrawl(&crawl.URLRequest{URL: golang}, stream)).Should(Succeed())
+			Ω(stream.sent).ShouldNot(BeEmpty())
+			Ω(stream.sent[0].Kind).Should(Equal(crawl.CrawlEvent_STATE_CHANGED))
+			last := stream.sent[len(stream.sent)-1]
+			Ω(last.Kind).Should(Equal(crawl.CrawlEvent_STATE_CHANGED))
+			Ω(last.State).Should(Equal("done"))
+		})
+		It("stops when the crawl is deleted", func() {
+			d := New(f, Config{})
+			d.Start(golang, crawler.Options{Politeness: crawler.Politeness{MinDelay: time.Second}})
+			d.Pause(golang)
+			stream := &watchStream{ctx: context.Background(), watching: make(chan bool, 1)}
+			watched := make(chan error)
+			go func() {
+				watched <- d.WatchCrawl(&crawl.URLRequest{URL: golang}, stream)
+			}()
+			<-stream.watching
+			d.Delete([]string{golang})
+			Eventually(watched).Should(Receive(BeNil()))
+		})
+		It("says so if there's nothing to watch", func() {
+			delete(s.crawlers, missing)
+			err := s.WatchCrawl(&crawl.URLRequest{URL: missing}, &watchStream{ctx: context.Background()})
+			Ω(err).Should(MatchError(ContainSubstring("has not been crawled")))
+		})
+	})
 })
 
+// watchStream stands in for the gRPC stream WatchCrawl sends on.
+type watchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*crawl.CrawlEvent
+	// watching, if set, is told when the first event is sent.
+	watching chan bool
+}
+
+func (w *watchStream) Send(e *crawl.CrawlEvent) error {
+	if w.watching != nil && len(w.sent) == 0 {
+		w.watching <- true
+	}
+	w.sent = append(w.sent, e)
+	return nil
+}
+
+func (w *watchStream) Context() context.Context {
+	return w.ctx
+}
+
+// resultStream stands in for the gRPC stream CrawlResult sends on.
+type resultStream struct {
+	grpc.ServerStream
+	sent []*crawl.SiteNode
+}
+
+func (r *resultStream) Send(node *crawl.SiteNode) error {
+	r.sent = append(r.sent, node)
+	return nil
+}
+
+// findNode returns the node for URL in the tree, or nil.
+func findNode(node *crawl.SiteNode, URL string) *crawl.SiteNode {
+	if node.SiteURL == URL {
+		return node
+	}
+	for _, child := range node.Children {
+		if found := findNode(child, URL); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
 func TestThings(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "API server Suite")
@@ -0,0 +1,114 @@
+package Server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/joemcmahon/joe_macmahon_technical_test/crawler"
+	log "github.com/sirupsen/logrus"
+)
+
+// store keeps a snapshot file for each crawl in a data directory, so
+// that crawls survive a server restart. A store with no directory
+// saves nothing.
+type store struct {
+	dir string
+}
+
+// savedCrawl is what we keep on disk for a crawl.
+type savedCrawl struct {
+	URL   string
+	State string
+	Crawl crawler.Snapshot
+}
+
+const snapshotSuffix = ".json"
+
+// path is the snapshot file for a crawl. It's named for a hash of the
+// URL, which makes a safe file name however long the URL is; the URL
+// itself is in the snapshot.
+func (s store) path(URL string) string {
+	sum := sha256.Sum256([]byte(URL))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+snapshotSuffix)
+}
+
+// save writes a crawl's snapshot. It's written to a temporary file
+// and renamed into place, so a crash never leaves a partial snapshot.
+func (s store) save(saved savedCrawl) error {
+	if s.dir == "" {
+		return nil
+	}
+	data, err := json.Marshal(saved)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(s.dir, "snapshot")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(saved.URL))
+}
+
+// load reads every snapshot in the data directory. Snapshots that
+// can't be read are logged and skipped.
+func (s store) load() []savedCrawl {
+	if s.dir == "" {
+		return nil
+	}
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		log.Errorf("can't read data directory %s: %s", s.dir, err.Error())
+		return nil
+	}
+	crawls := []savedCrawl{}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), snapshotSuffix) {
+			continue
+		}
+		name := filepath.Join(s.dir, file.Name())
+		data, err := ioutil.ReadFile(name)
+		if err != nil {
+			log.Errorf("can't read snapshot %s: %s", name, err.Error())
+			continue
+		}
+		var saved savedCrawl
+		if err := json.Unmarshal(data, &saved); err != nil {
+			log.Errorf("can't parse snapshot %s: %s", name, err.Error())
+			continue
+		}
+		if want := s.path(saved.URL); name != want {
+			// Saved under an older naming scheme; move it, so
+			// that saving and removing the crawl find it.
+			if err := os.Rename(name, want); err != nil {
+				log.Errorf("can't rename snapshot %s: %s", name, err.Error())
+			}
+		}
+		crawls = append(crawls, saved)
+	}
+	return crawls
+}
+
+// remove deletes a crawl's snapshot, if it has one.
+func (s store) remove(URL string) error {
+	if s.dir == "" {
+		return nil
+	}
+	if err := os.Remove(s.path(URL)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
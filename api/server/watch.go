@@ -0,0 +1,96 @@
+package Server
+
+import (
+	"time"
+
+	"github.com/joemcmahon/joe_macmahon_technical_test/api/crawl"
+	"github.com/joemcmahon/joe_macmahon_technical_test/crawler"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WatchCrawl sends the events for a crawl as they happen. It starts
+// by sending the crawl's current state, and ends when the crawl
+// finishes or the client goes away.
+func (c *CrawlServer) WatchCrawl(req *crawl.URLRequest, stream crawl.Crawl_WatchCrawlServer) error {
+	c.mutex.Lock()
+	url := c.lookup(req.URL, c.defaults.Normalization)
+	control, ok := c.crawlers[url]
+	c.mutex.Unlock()
+	if !ok || control.crawler == nil {
+		return status.Errorf(codes.NotFound, "%s has not been crawled", req.URL)
+	}
+
+	// Start watching before we look at the state, so nothing is missed.
+	events, cancel := control.crawler.Watch()
+	defer cancel()
+
+	state := c.Probe(url)
+	if err := stream.Send(stateChanged(time.Now(), state)); err != nil {
+		return err
+	}
+	switch saveableState(state) {
+	case done, limited, failed:
+		return nil
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			log.Debugf("watcher for %s went away", req.URL)
+			return nil
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(c.event(url, e)); err != nil {
+				return err
+			}
+			if e.Kind == crawler.Finished {
+				return nil
+			}
+		}
+	}
+}
+
+// event converts a crawler event for sending.
+func (c *CrawlServer) event(url string, e crawler.Event) *crawl.CrawlEvent {
+	switch e.Kind {
+	case crawler.Started, crawler.Resumed:
+		return stateChanged(e.Time, translate(running))
+	case crawler.Paused:
+		return stateChanged(e.Time, translate(stopped))
+	case crawler.Finished:
+		// The server decides whether it's done or hit a limit.
+		return stateChanged(e.Time, c.Probe(url))
+	}
+	ce := &crawl.CrawlEvent{
+		TimeMillis:    millis(e.Time),
+		URL:           e.URL,
+		HttpStatus:    int32(e.Status),
+		ElapsedMillis: int64(e.Elapsed / time.Millisecond),
+		Error:         e.Error,
+	}
+	switch e.Kind {
+	case crawler.Queued:
+		ce.Kind = crawl.CrawlEvent_QUEUED
+	case crawler.PageFetched:
+		ce.Kind = crawl.CrawlEvent_FETCHED
+	case crawler.PageFailed:
+		ce.Kind = crawl.CrawlEvent_FAILED
+	}
+	return ce
+}
+
+func stateChanged(t time.Time, state string) *crawl.CrawlEvent {
+	return &crawl.CrawlEvent{
+		Kind:       crawl.CrawlEvent_STATE_CHANGED,
+		TimeMillis: millis(t),
+		State:      state,
+	}
+}
+
+func millis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
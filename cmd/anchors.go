@@ -0,0 +1,103 @@
+// Copyright © 2018 Joe McMahon <joe.mcmahon@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/joemcmahon/joe_macmahon_technical_test/api/client"
+	pb "github.com/joemcmahon/joe_macmahon_technical_test/api/crawl"
+	"github.com/spf13/cobra"
+)
+
+const anchorsUsage = `Usage: crawl anchors <url>
+
+Lists the links in the crawl of <url> to fragments that aren't there.`
+
+// anchorsCmd represents the anchors command
+var anchorsCmd = &cobra.Command{
+	Use:   "anchors",
+	Short: "List the links to missing fragments in a crawl",
+	Long: `Lists every link in a crawl to a fragment -- page.html#section --
+that isn't an id or <a name> on the page it points at, with the page
+the link is on and its text. Links to pages that weren't fetched, or
+aren't HTML, can't be checked.
+
+  --output=FMT   table (the default), csv, or json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 1 {
+			fmt.Println(anchorsUsage)
+			return
+		}
+		anchors(args[0])
+	},
+}
+
+var anchorsOutput string
+
+// brokenAnchor is a missing fragment as printed in JSON.
+type brokenAnchor struct {
+	URL        string       `json:"url"`
+	Fragment   string       `json:"fragment"`
+	LinkedFrom []linkSource `json:"linkedFrom"`
+}
+
+func anchors(url string) {
+	runReport(anchorsOutput, "broken anchors", []string{outputTable, outputCSV, outputJSON}, func(ctx context.Context, c *Client.CrawlClient) (*report, error) {
+		found, err := c.BrokenAnchors(ctx, &pb.URLRequest{URL: url})
+		if err != nil {
+			return nil, err
+		}
+		return anchorsReport(found), nil
+	})
+}
+
+// anchorsReport renders the missing fragments the server found.
+func anchorsReport(found *pb.BrokenAnchorReport) *report {
+	missing := []brokenAnchor{}
+	for _, b := range found.Anchors {
+		anchor := brokenAnchor{URL: b.URL, Fragment: b.Fragment, LinkedFrom: []linkSource{}}
+		for _, from := range b.LinkedFrom {
+			anchor.LinkedFrom = append(anchor.LinkedFrom, linkSource{Source: from.Source, Text: from.Text})
+		}
+		missing = append(missing, anchor)
+	}
+
+	// One row for each link to a missing fragment.
+	rows := [][]string{}
+	for _, anchor := range missing {
+		for _, from := range anchor.LinkedFrom {
+			rows = append(rows, []string{anchor.URL + "#" + anchor.Fragment, from.Source, from.Text})
+		}
+	}
+	return &report{
+		data:   missing,
+		header: []string{"LINK", "LINKED FROM", "TEXT"},
+		rows:   rows,
+		none:   "No broken anchors found",
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(anchorsCmd)
+	anchorsCmd.Flags().StringVarP(&anchorsOutput, "output", "o", outputTable, "output format: table, csv, or json")
+}
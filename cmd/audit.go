@@ -0,0 +1,112 @@
+// Copyright © 2018 Joe McMahon <joe.mcmahon@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/joemcmahon/joe_macmahon_technical_test/api/client"
+	pb "github.com/joemcmahon/joe_macmahon_technical_test/api/crawl"
+	"github.com/spf13/cobra"
+)
+
+const auditUsage = `Usage: crawl audit <url>
+
+Lists the pages in the crawl of <url> with problems a search engine would notice.`
+
+// auditCmd represents the audit command
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "List the pages in a crawl with SEO problems",
+	Long: `Checks what every page in a crawl says about itself, and lists
+the pages with problems a search engine would notice: a missing title,
+a title another page has too, a missing meta description, more than
+one h1, or too few words to be worth indexing (a thin page).
+
+  --output=FMT   table (the default) or json; json includes each
+                 page's title, description, h1s, meta robots,
+                 language, word count and number of links`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 1 {
+			fmt.Println(auditUsage)
+			return
+		}
+		audit(args[0])
+	},
+}
+
+var auditOutput string
+
+// auditedPage is a page with problems as printed in JSON.
+type auditedPage struct {
+	URL       string   `json:"url"`
+	Problems  []string `json:"problems"`
+	SameTitle []string `json:"sameTitle,omitempty"`
+	Meta      pageMeta `json:"meta"`
+}
+
+func audit(url string) {
+	runReport(auditOutput, "audit", []string{outputTable, outputJSON}, func(ctx context.Context, c *Client.CrawlClient) (*report, error) {
+		found, err := c.Audit(ctx, &pb.URLRequest{URL: url})
+		if err != nil {
+			return nil, err
+		}
+		return auditReport(found), nil
+	})
+}
+
+// auditReport renders the pages with problems the server found.
+func auditReport(found *pb.AuditReport) *report {
+	pages := []auditedPage{}
+	for _, p := range found.Pages {
+		page := auditedPage{URL: p.URL, Problems: p.Problems, SameTitle: p.SameTitle}
+		if meta := pageMetaFor(p.Meta); meta != nil {
+			page.Meta = *meta
+		}
+		pages = append(pages, page)
+	}
+
+	rows := [][]string{}
+	for _, page := range pages {
+		problems := []string{}
+		for _, problem := range page.Problems {
+			if len(page.SameTitle) > 0 && problem == "duplicate title" {
+				problem += " (as " + strings.Join(page.SameTitle, ", ") + ")"
+			}
+			problems = append(problems, problem)
+		}
+		rows = append(rows, []string{page.URL, page.Meta.Title, strconv.Itoa(page.Meta.Words), strings.Join(problems, ", ")})
+	}
+	return &report{
+		data:   pages,
+		header: []string{"URL", "TITLE", "WORDS", "PROBLEMS"},
+		rows:   rows,
+		none:   "No problems found",
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.Flags().StringVarP(&auditOutput, "output", "o", outputTable, "output format: table or json")
+}
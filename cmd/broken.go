@@ -0,0 +1,118 @@
+// Copyright © 2018 Joe McMahon <joe.mcmahon@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/joemcmahon/joe_macmahon_technical_test/api/client"
+	pb "github.com/joemcmahon/joe_macmahon_technical_test/api/crawl"
+	"github.com/spf13/cobra"
+)
+
+const brokenUsage = `Usage: crawl broken <url>
+
+Lists the links in the crawl of <url> that don't work.`
+
+// brokenCmd represents the broken command
+var brokenCmd = &cobra.Command{
+	Use:   "broken",
+	Short: "List the broken links in a crawl",
+	Long: `Lists every URL in a crawl that couldn't be fetched -- an HTTP
+error, a network failure, or a URL that isn't valid -- with every page
+that links to it and the text of the link.
+
+  --output=FMT   table (the default), csv, or json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 1 {
+			fmt.Println(brokenUsage)
+			return
+		}
+		broken(args[0])
+	},
+}
+
+var brokenOutput string
+
+// brokenLink is a broken link as printed by crawl broken.
+type brokenLink struct {
+	URL        string       `json:"url"`
+	Status     int          `json:"status,omitempty"`
+	Kind       string       `json:"kind"`
+	Error      string       `json:"error"`
+	LinkedFrom []linkSource `json:"linkedFrom"`
+}
+
+func broken(url string) {
+	runReport(brokenOutput, "broken links", []string{outputTable, outputCSV, outputJSON}, func(ctx context.Context, c *Client.CrawlClient) (*report, error) {
+		found, err := c.BrokenLinks(ctx, &pb.URLRequest{URL: url})
+		if err != nil {
+			return nil, err
+		}
+		return brokenReport(found), nil
+	})
+}
+
+// brokenReport renders the broken links the server found.
+func brokenReport(found *pb.BrokenLinkReport) *report {
+	links := []brokenLink{}
+	for _, b := range found.Links {
+		link := brokenLink{
+			URL:        b.URL,
+			Status:     int(b.HttpStatus),
+			Kind:       errorKind(b.ErrorKind),
+			Error:      b.Error,
+			LinkedFrom: []linkSource{},
+		}
+		for _, from := range b.LinkedFrom {
+			link.LinkedFrom = append(link.LinkedFrom, linkSource{Source: from.Source, Text: from.Text})
+		}
+		links = append(links, link)
+	}
+
+	// One row for each link to a broken URL.
+	rows := [][]string{}
+	for _, link := range links {
+		code := ""
+		if link.Status != 0 {
+			code = strconv.Itoa(link.Status)
+		}
+		if len(link.LinkedFrom) == 0 {
+			rows = append(rows, []string{link.URL, code, link.Kind, link.Error, "", ""})
+		}
+		for _, from := range link.LinkedFrom {
+			rows = append(rows, []string{link.URL, code, link.Kind, link.Error, from.Source, from.Text})
+		}
+	}
+	return &report{
+		data:   links,
+		header: []string{"URL", "STATUS", "KIND", "ERROR", "LINKED FROM", "TEXT"},
+		rows:   rows,
+		none:   "No broken links found",
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(brokenCmd)
+	brokenCmd.Flags().StringVarP(&brokenOutput, "output", "o", outputTable, "output format: table, csv, or json")
+}
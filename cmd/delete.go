@@ -0,0 +1,102 @@
+// Copyright © 2018 Joe McMahon <joe.mcmahon@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/joemcmahon/joe_macmahon_technical_test/api/client"
+	pb "github.com/joemcmahon/joe_macmahon_technical_test/api/crawl"
+	"github.com/spf13/cobra"
+)
+
+const deleteUsage = `Usage: crawl delete <url>
+       crawl delete --all
+       crawl delete --state=<state>
+
+Stops crawls and throws away their results.`
+
+// deleteCmd represents the delete command
+var deleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Stop a crawl and forget it",
+	Long: `Stops the crawl for a URL and throws away its results, including
+anything the server has saved. --all deletes every crawl; --state
+deletes every crawl in that state (running, stopped, done, failed, or
+limit-reached), and may be repeated.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		remove(args)
+	},
+}
+
+var (
+	deleteAll    bool
+	deleteStates []string
+)
+
+func remove(args []string) {
+	req := &pb.DeleteRequest{All: deleteAll}
+	for _, s := range deleteStates {
+		state, err := parseState(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		req.States = append(req.States, state)
+	}
+	if len(args) > 0 {
+		req.URL = args[0]
+	}
+	if req.URL == "" && !req.All && len(req.States) == 0 {
+		fmt.Println(deleteUsage)
+		return
+	}
+	if req.URL != "" && (req.All || len(req.States) > 0) {
+		fmt.Println("Give a URL, or --all, or --state; not more than one.")
+		return
+	}
+
+	c := Client.New(addr)
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	resp, err := c.DeleteCrawl(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to delete crawl: %s\n", err.Error())
+		return
+	}
+	if len(resp.Deleted) == 0 {
+		fmt.Println("No crawls deleted")
+		return
+	}
+	for _, url := range resp.Deleted {
+		fmt.Println("Deleted", url)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(deleteCmd)
+	deleteCmd.Flags().BoolVar(&deleteAll, "all", false, "delete every crawl")
+	deleteCmd.Flags().StringSliceVar(&deleteStates, "state", nil, "delete every crawl in this state (may be repeated)")
+}
@@ -0,0 +1,107 @@
+// Copyright © 2018 Joe McMahon <joe.mcmahon@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/joemcmahon/joe_macmahon_technical_test/api/client"
+	pb "github.com/joemcmahon/joe_macmahon_technical_test/api/crawl"
+	"github.com/spf13/cobra"
+)
+
+const duplicatesUsage = `Usage: crawl duplicates <url>
+
+Lists the pages in the crawl of <url> that are the same as other pages.`
+
+// duplicatesCmd represents the duplicates command
+var duplicatesCmd = &cobra.Command{
+	Use:   "duplicates",
+	Short: "List the duplicate pages in a crawl",
+	Long: `Lists every page in a crawl that is the same as another page,
+grouped under the page it duplicates: pages whose <link rel="canonical">
+names another URL, and pages with the same text as one already crawled.
+The crawl doesn't follow the links on duplicates.
+
+  --output=FMT   table (the default), csv, or json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 1 {
+			fmt.Println(duplicatesUsage)
+			return
+		}
+		duplicates(args[0])
+	},
+}
+
+var duplicatesOutput string
+
+// duplicateGroup is a page and its duplicates as printed in JSON.
+type duplicateGroup struct {
+	URL        string          `json:"url"`
+	Duplicates []duplicatePage `json:"duplicates"`
+}
+
+type duplicatePage struct {
+	URL    string `json:"url"`
+	Reason string `json:"reason"`
+}
+
+func duplicates(url string) {
+	runReport(duplicatesOutput, "duplicates", []string{outputTable, outputCSV, outputJSON}, func(ctx context.Context, c *Client.CrawlClient) (*report, error) {
+		found, err := c.Duplicates(ctx, &pb.URLRequest{URL: url})
+		if err != nil {
+			return nil, err
+		}
+		return duplicatesReport(found), nil
+	})
+}
+
+// duplicatesReport renders the duplicate pages the server found.
+func duplicatesReport(found *pb.DuplicateReport) *report {
+	groups := []duplicateGroup{}
+	for _, g := range found.Groups {
+		group := duplicateGroup{URL: g.URL, Duplicates: []duplicatePage{}}
+		for _, dup := range g.Duplicates {
+			group.Duplicates = append(group.Duplicates, duplicatePage{URL: dup.URL, Reason: dup.Reason})
+		}
+		groups = append(groups, group)
+	}
+
+	// One row for each duplicate.
+	rows := [][]string{}
+	for _, group := range groups {
+		for _, dup := range group.Duplicates {
+			rows = append(rows, []string{group.URL, dup.URL, dup.Reason})
+		}
+	}
+	return &report{
+		data:   groups,
+		header: []string{"PAGE", "DUPLICATE", "REASON"},
+		rows:   rows,
+		none:   "No duplicate pages found",
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(duplicatesCmd)
+	duplicatesCmd.Flags().StringVarP(&duplicatesOutput, "output", "o", outputTable, "output format: table, csv, or json")
+}
@@ -0,0 +1,277 @@
+// Copyright © 2018 Joe McMahon <joe.mcmahon@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	pb "github.com/joemcmahon/joe_macmahon_technical_test/api/crawl"
+	"github.com/spf13/cobra"
+)
+
+const exportUsage = `Usage: crawl export [--format=dot|graphml] <url>
+
+Writes the link graph of the crawl of <url>.`
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a crawl's link graph",
+	Long: `Writes the link graph of a crawl -- every URL it found, and every
+link between them -- for a graph tool to draw. Nodes are colored by what
+happened when the URL was fetched, and offsite URLs are drawn dashed.
+
+  --format=FMT   dot (Graphviz, the default) or graphml`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 1 {
+			fmt.Println(exportUsage)
+			return
+		}
+		export(args[0])
+	},
+}
+
+const (
+	exportDOT     = "dot"
+	exportGraphML = "graphml"
+)
+
+var exportFormat string
+
+// Node colors for each outcome.
+var outcomeColors = map[pb.SiteNode_Outcome]string{
+	pb.SiteNode_PENDING:      "lightyellow",
+	pb.SiteNode_FETCHING:     "lightyellow",
+	pb.SiteNode_FETCHED:      "palegreen",
+	pb.SiteNode_FAILED:       "salmon",
+	pb.SiteNode_INVALID:      "red",
+	pb.SiteNode_OFFSITE:      "lightgrey",
+	pb.SiteNode_BLOCKED:      "orange",
+	pb.SiteNode_UNFETCHED:    "lightblue",
+	pb.SiteNode_OUT_OF_SCOPE: "thistle",
+	pb.SiteNode_REDIRECTED:   "khaki",
+	pb.SiteNode_DUPLICATE:    "wheat",
+}
+
+// graphEdge is a link from one page to another.
+type graphEdge struct {
+	source, target, text string
+}
+
+// linkGraph flattens the crawl tree into its nodes, in tree order, and
+// every link between them -- not just the ones that made the tree.
+// Links from pages that aren't in the tree (such as duplicates) are
+// left out, so that every edge joins two nodes.
+func linkGraph(root *pb.SiteNode) ([]*pb.SiteNode, []graphEdge) {
+	nodes := []*pb.SiteNode{}
+	links := []graphEdge{}
+	inTree := map[string]bool{}
+	var walk func(*pb.SiteNode)
+	walk = func(node *pb.SiteNode) {
+		nodes = append(nodes, node)
+		inTree[node.SiteURL] = true
+		for _, link := range node.LinkedFrom {
+			links = append(links, graphEdge{source: link.Source, target: node.SiteURL, text: link.Text})
+		}
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(root)
+
+	edges := []graphEdge{}
+	for _, edge := range links {
+		if inTree[edge.source] {
+			edges = append(edges, edge)
+		}
+	}
+	return nodes, edges
+}
+
+func export(url string) {
+	if err := checkOutput(exportFormat, exportDOT, exportGraphML); err != nil {
+		fmt.Println(err)
+		return
+	}
+	root := result(url)
+	if root == nil {
+		return
+	}
+	var err error
+	if exportFormat == exportGraphML {
+		err = writeGraphML(os.Stdout, root)
+	} else {
+		err = writeDOT(os.Stdout, root)
+	}
+	if err != nil {
+		fmt.Println(err)
+	}
+}
+
+// writeDOT writes the link graph in Graphviz's DOT language.
+func writeDOT(w io.Writer, root *pb.SiteNode) error {
+	nodes, edges := linkGraph(root)
+	lines := []string{
+		"digraph crawl {",
+		"  rankdir=LR;",
+		"  node [shape=box, style=filled];",
+	}
+	for _, node := range nodes {
+		attrs := fmt.Sprintf("fillcolor=%s, tooltip=%s", outcomeColors[node.Outcome], dotQuote(outcomeName(node.Outcome)))
+		switch {
+		case node.Outcome == pb.SiteNode_OFFSITE:
+			attrs += `, shape=ellipse, style="filled,dashed"`
+		case node.Asset != "":
+			attrs += ", shape=note"
+		}
+		lines = append(lines, fmt.Sprintf("  %s [%s];", dotQuote(node.SiteURL), attrs))
+	}
+	for _, edge := range edges {
+		line := fmt.Sprintf("  %s -> %s", dotQuote(edge.source), dotQuote(edge.target))
+		if edge.text != "" {
+			line += fmt.Sprintf(" [label=%s]", dotQuote(edge.text))
+		}
+		lines = append(lines, line+";")
+	}
+	lines = append(lines, "}")
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+// dotQuote makes s a DOT string.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// The GraphML document, as encoding/xml sees it.
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// The attributes we record for nodes and edges.
+var graphMLKeys = []graphMLKey{
+	{ID: "url", For: "node", Name: "url", Type: "string"},
+	{ID: "outcome", For: "node", Name: "outcome", Type: "string"},
+	{ID: "status", For: "node", Name: "status", Type: "int"},
+	{ID: "error", For: "node", Name: "error", Type: "string"},
+	{ID: "color", For: "node", Name: "color", Type: "string"},
+	{ID: "offsite", For: "node", Name: "offsite", Type: "boolean"},
+	{ID: "asset", For: "node", Name: "asset", Type: "string"},
+	{ID: "text", For: "edge", Name: "text", Type: "string"},
+}
+
+// writeGraphML writes the link graph as GraphML. Nodes are identified
+// by number, with the URL as an attribute.
+func writeGraphML(w io.Writer, root *pb.SiteNode) error {
+	nodes, edges := linkGraph(root)
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys:  graphMLKeys,
+		Graph: graphMLGraph{ID: "crawl", EdgeDefault: "directed"},
+	}
+	ids := map[string]string{}
+	for i, node := range nodes {
+		id := "n" + strconv.Itoa(i)
+		ids[node.SiteURL] = id
+		data := []graphMLData{
+			{Key: "url", Value: node.SiteURL},
+			{Key: "outcome", Value: outcomeName(node.Outcome)},
+			{Key: "color", Value: outcomeColors[node.Outcome]},
+			{Key: "offsite", Value: strconv.FormatBool(node.Outcome == pb.SiteNode_OFFSITE)},
+		}
+		if node.HttpStatus != 0 {
+			data = append(data, graphMLData{Key: "status", Value: strconv.Itoa(int(node.HttpStatus))})
+		}
+		if node.Error != "" {
+			data = append(data, graphMLData{Key: "error", Value: node.Error})
+		}
+		if node.Asset != "" {
+			data = append(data, graphMLData{Key: "asset", Value: node.Asset})
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{ID: id, Data: data})
+	}
+	for i, edge := range edges {
+		e := graphMLEdge{
+			ID:     "e" + strconv.Itoa(i),
+			Source: ids[edge.source],
+			Target: ids[edge.target],
+		}
+		if edge.text != "" {
+			e.Data = []graphMLData{{Key: "text", Value: edge.text}}
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, e)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", exportDOT, "output format: dot or graphml")
+}
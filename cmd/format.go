@@ -0,0 +1,294 @@
+// Copyright © 2018 Joe McMahon <joe.mcmahon@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/disiqueira/gotree"
+	pb "github.com/joemcmahon/joe_macmahon_technical_test/api/crawl"
+	"github.com/joemcmahon/joe_macmahon_technical_test/crawler/page"
+)
+
+// Added to the tree entry for a URL that robots.txt won't let us fetch.
+const blockedByRobots = " (blocked by robots)"
+
+// Added to the tree entry for a URL the crawl's patterns exclude.
+const outOfScope = " (out of scope)"
+
+// Added to the tree entry for a URL that redirects off the site.
+const redirectedOffsite = " (redirects offsite)"
+
+// Added to the tree entry for a URL queued from the sitemap.
+const fromSitemap = " (sitemap)"
+
+// formatTree renders a crawl tree as text, followed by a list
+// of the orphaned pages, if there are any.
+func formatTree(root *pb.SiteNode) string {
+	t := gotree.New(label(root))
+	addChildren(t, root)
+	display := t.Print()
+
+	if orphans := orphans(root); len(orphans) > 0 {
+		display += "\nOrphaned pages (in the sitemap, but not linked to):\n"
+		display += strings.Join(orphans, "\n") + "\n"
+	}
+	return display
+}
+
+// addChildren adds the children of node to the tree under t.
+func addChildren(t gotree.Tree, node *pb.SiteNode) {
+	for _, child := range node.Children {
+		addChildren(t.Add(label(child)), child)
+	}
+}
+
+// label is the text shown for a node in the tree.
+func label(node *pb.SiteNode) string {
+	l := node.SiteURL
+	if node.Asset != "" {
+		l += " [" + node.Asset + "]"
+	}
+	switch node.Outcome {
+	case pb.SiteNode_BLOCKED:
+		l += blockedByRobots
+	case pb.SiteNode_OUT_OF_SCOPE:
+		l += outOfScope
+	case pb.SiteNode_REDIRECTED:
+		// Where it goes is its only child.
+		l += redirectedOffsite
+	case pb.SiteNode_DUPLICATE:
+		l += " (duplicate of " + node.DuplicateOf + ")"
+	case pb.SiteNode_FAILED, pb.SiteNode_INVALID:
+		// The error says what went wrong, including any HTTP status.
+		l += " (" + node.Error + ")"
+	}
+	if node.FinalURL != "" && node.FinalURL != node.SiteURL && node.Outcome == pb.SiteNode_FETCHED {
+		l += " -> " + node.FinalURL
+	}
+	if node.Sitemap {
+		l += fromSitemap
+	}
+	return l
+}
+
+// orphans lists the orphaned pages in the tree, sorted.
+func orphans(root *pb.SiteNode) []string {
+	found := map[string]bool{}
+	var walk func(*pb.SiteNode)
+	walk = func(node *pb.SiteNode) {
+		if node.Orphaned {
+			found[node.SiteURL] = true
+		}
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(root)
+
+	list := []string{}
+	for URL := range found {
+		list = append(list, URL)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// siteEntry is a node in the crawl tree as printed in JSON or YAML.
+type siteEntry struct {
+	URL string `json:"url" yaml:"url"`
+	// State is the state of the crawl; only set on the root.
+	State           string       `json:"state,omitempty" yaml:"state,omitempty"`
+	Parent          string       `json:"parent,omitempty" yaml:"parent,omitempty"`
+	Depth           int          `json:"depth" yaml:"depth"`
+	Outcome         string       `json:"outcome" yaml:"outcome"`
+	Asset           string       `json:"asset,omitempty" yaml:"asset,omitempty"`
+	Status          int          `json:"status,omitempty" yaml:"status,omitempty"`
+	FinalURL        string       `json:"finalURL,omitempty" yaml:"finalURL,omitempty"`
+	ContentType     string       `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+	Size            int64        `json:"size,omitempty" yaml:"size,omitempty"`
+	LatencyMillis   int64        `json:"latencyMillis,omitempty" yaml:"latencyMillis,omitempty"`
+	FirstByteMillis int64        `json:"firstByteMillis,omitempty" yaml:"firstByteMillis,omitempty"`
+	Error           string       `json:"error,omitempty" yaml:"error,omitempty"`
+	ErrorKind       string       `json:"errorKind,omitempty" yaml:"errorKind,omitempty"`
+	Sitemap         bool         `json:"sitemap,omitempty" yaml:"sitemap,omitempty"`
+	Orphaned        bool         `json:"orphaned,omitempty" yaml:"orphaned,omitempty"`
+	Anchors         []string     `json:"anchors,omitempty" yaml:"anchors,omitempty"`
+	Redirects       []redirect   `json:"redirects,omitempty" yaml:"redirects,omitempty"`
+	Canonical       string       `json:"canonical,omitempty" yaml:"canonical,omitempty"`
+	ContentHash     string       `json:"contentHash,omitempty" yaml:"contentHash,omitempty"`
+	DuplicateOf     string       `json:"duplicateOf,omitempty" yaml:"duplicateOf,omitempty"`
+	DuplicateReason string       `json:"duplicateReason,omitempty" yaml:"duplicateReason,omitempty"`
+	Meta            *pageMeta    `json:"meta,omitempty" yaml:"meta,omitempty"`
+	LinkedFrom      []linkSource `json:"linkedFrom,omitempty" yaml:"linkedFrom,omitempty"`
+	Children        []*siteEntry `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// linkSource is a link to a page: the page it's on, and its text.
+type linkSource struct {
+	Source string `json:"source" yaml:"source"`
+	Text   string `json:"text" yaml:"text"`
+}
+
+// redirect is one hop in a chain of redirects.
+type redirect struct {
+	URL      string `json:"url" yaml:"url"`
+	Status   int    `json:"status" yaml:"status"`
+	Location string `json:"location" yaml:"location"`
+}
+
+// redirectsFor converts a chain of redirects for printing.
+func redirectsFor(hops []*pb.Redirect) []redirect {
+	var chain []redirect
+	for _, hop := range hops {
+		chain = append(chain, redirect{URL: hop.URL, Status: int(hop.HttpStatus), Location: hop.Location})
+	}
+	return chain
+}
+
+// pageMeta is what a page says about itself.
+type pageMeta struct {
+	Title         string   `json:"title,omitempty" yaml:"title,omitempty"`
+	Description   string   `json:"description,omitempty" yaml:"description,omitempty"`
+	H1s           []string `json:"h1s,omitempty" yaml:"h1s,omitempty"`
+	Robots        string   `json:"robots,omitempty" yaml:"robots,omitempty"`
+	Language      string   `json:"language,omitempty" yaml:"language,omitempty"`
+	Words         int      `json:"words" yaml:"words"`
+	OutboundLinks int      `json:"outboundLinks" yaml:"outboundLinks"`
+}
+
+// pageMetaFor converts what a page says about itself for printing;
+// nil if it says nothing.
+func pageMetaFor(m *pb.PageMeta) *pageMeta {
+	if m == nil {
+		return nil
+	}
+	return &pageMeta{
+		Title:         m.Title,
+		Description:   m.Description,
+		H1s:           m.H1S,
+		Robots:        m.Robots,
+		Language:      m.Language,
+		Words:         int(m.Words),
+		OutboundLinks: int(m.OutboundLinks),
+	}
+}
+
+// siteEntryFor converts a node, and everything under it, for printing.
+func siteEntryFor(node *pb.SiteNode) *siteEntry {
+	e := &siteEntry{
+		URL:             node.SiteURL,
+		State:           node.Status,
+		Parent:          node.Parent,
+		Depth:           int(node.Depth),
+		Outcome:         outcomeName(node.Outcome),
+		Asset:           node.Asset,
+		Status:          int(node.HttpStatus),
+		FinalURL:        node.FinalURL,
+		ContentType:     node.ContentType,
+		Size:            node.Size,
+		LatencyMillis:   node.LatencyMillis,
+		FirstByteMillis: node.FirstByteMillis,
+		Error:           node.Error,
+		ErrorKind:       errorKind(node.ErrorKind),
+		Sitemap:         node.Sitemap,
+		Orphaned:        node.Orphaned,
+		Anchors:         node.Anchors,
+		Canonical:       node.Canonical,
+		ContentHash:     node.ContentHash,
+		DuplicateOf:     node.DuplicateOf,
+		DuplicateReason: node.DuplicateReason,
+	}
+	e.Redirects = redirectsFor(node.Redirects)
+	e.Meta = pageMetaFor(node.Meta)
+	for _, link := range node.LinkedFrom {
+		e.LinkedFrom = append(e.LinkedFrom, linkSource{Source: link.Source, Text: link.Text})
+	}
+	for _, child := range node.Children {
+		e.Children = append(e.Children, siteEntryFor(child))
+	}
+	return e
+}
+
+// The columns of the flat CSV version of the tree.
+var siteHeader = []string{"url", "parent", "depth", "status", "outcome", "asset", "error"}
+
+// siteRows flattens the tree into one row per URL, in tree order.
+func siteRows(root *pb.SiteNode) [][]string {
+	rows := [][]string{}
+	var walk func(*pb.SiteNode)
+	walk = func(node *pb.SiteNode) {
+		status := ""
+		if node.HttpStatus != 0 {
+			status = strconv.Itoa(int(node.HttpStatus))
+		}
+		rows = append(rows, []string{
+			node.SiteURL, node.Parent, strconv.Itoa(int(node.Depth)),
+			status, outcomeName(node.Outcome), node.Asset, node.Error,
+		})
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(root)
+	return rows
+}
+
+// outcomeName is how an outcome is printed: "fetched", "out of scope", etc.
+func outcomeName(o pb.SiteNode_Outcome) string {
+	return strings.ToLower(strings.Replace(o.String(), "_", " ", -1))
+}
+
+// pageErrorKinds are the crawler's kinds of error, by what's sent.
+var pageErrorKinds = map[pb.SiteNode_ErrorKind]page.ErrorKind{
+	pb.SiteNode_OTHER_ERROR:        page.OtherError,
+	pb.SiteNode_HTTP_ERROR:         page.HTTPError,
+	pb.SiteNode_DNS_ERROR:          page.DNSError,
+	pb.SiteNode_CONNECTION_ERROR:   page.ConnectionError,
+	pb.SiteNode_TIMEOUT:            page.Timeout,
+	pb.SiteNode_INVALID_URL:        page.InvalidURL,
+	pb.SiteNode_REDIRECT_LOOP:      page.RedirectLoop,
+	pb.SiteNode_TOO_MANY_REDIRECTS: page.TooManyRedirects,
+}
+
+// errorKind is how a kind of error is printed, using the crawler's
+// names; empty if there was no error.
+func errorKind(kind pb.SiteNode_ErrorKind) string {
+	if k, ok := pageErrorKinds[kind]; ok {
+		return k.String()
+	}
+	return ""
+}
+
+// findNode returns the node for URL in the tree, or nil.
+func findNode(node *pb.SiteNode, URL string) *pb.SiteNode {
+	if node.SiteURL == URL {
+		return node
+	}
+	for _, child := range node.Children {
+		if found := findNode(child, URL); found != nil {
+			return found
+		}
+	}
+	return nil
+}
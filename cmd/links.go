@@ -0,0 +1,65 @@
+// Copyright © 2018 Joe McMahon <joe.mcmahon@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+const linksUsage = `Usage: crawl links <url> <page>
+
+Shows every link to <page> found by the crawl of <url>.`
+
+// linksCmd represents the links command
+var linksCmd = &cobra.Command{
+	Use:   "links",
+	Short: "Show what links to a page",
+	Long: `Lists every page in a crawl that links to the given page,
+with the text of each link.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 2 {
+			fmt.Println(linksUsage)
+			return
+		}
+		root := result(args[0])
+		if root == nil {
+			return
+		}
+		node := findNode(root, args[1])
+		if node == nil {
+			fmt.Printf("%s was not found by the crawl of %s\n", args[1], args[0])
+			return
+		}
+		if len(node.LinkedFrom) == 0 {
+			fmt.Printf("Nothing links to %s\n", args[1])
+			return
+		}
+		for _, link := range node.LinkedFrom {
+			fmt.Printf("%s %q\n", link.Source, link.Text)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(linksCmd)
+}
@@ -0,0 +1,173 @@
+// Copyright © 2018 Joe McMahon <joe.mcmahon@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joemcmahon/joe_macmahon_technical_test/api/client"
+	pb "github.com/joemcmahon/joe_macmahon_technical_test/api/crawl"
+	"github.com/spf13/cobra"
+)
+
+// listCmd represents the list command
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all crawls and their status",
+	Long: `Lists every crawl the server knows about, with its state, when it
+started, how many pages it has fetched, how many URLs are waiting, and
+how many have failed.
+
+  --state=STATE  only list crawls in STATE (running, stopped, done,
+                 failed, or limit-reached); may be repeated
+  --sort=FIELD   sort by url, state, started, fetched, queued, or errors
+  --output=FMT   table (the default) or json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		list()
+	},
+}
+
+var (
+	listStates []string
+	listSort   string
+	listOutput string
+)
+
+// crawlSummary is a crawl as printed by crawl list.
+type crawlSummary struct {
+	URL     string     `json:"url"`
+	State   string     `json:"state"`
+	Started *time.Time `json:"started,omitempty"`
+	Fetched int        `json:"fetched"`
+	Queued  int        `json:"queued"`
+	Errors  int        `json:"errors"`
+}
+
+// How crawl list can sort.
+var listSorts = map[string]func(a, b crawlSummary) bool{
+	"url":     func(a, b crawlSummary) bool { return a.URL < b.URL },
+	"state":   func(a, b crawlSummary) bool { return a.State < b.State },
+	"started": func(a, b crawlSummary) bool { return started(a).Before(started(b)) },
+	"fetched": func(a, b crawlSummary) bool { return a.Fetched > b.Fetched },
+	"queued":  func(a, b crawlSummary) bool { return a.Queued > b.Queued },
+	"errors":  func(a, b crawlSummary) bool { return a.Errors > b.Errors },
+}
+
+func list() {
+	less, ok := listSorts[listSort]
+	if !ok {
+		fmt.Printf("can't sort by %q\n", listSort)
+		return
+	}
+	if err := checkOutput(listOutput, outputTable, outputJSON); err != nil {
+		fmt.Println(err)
+		return
+	}
+	req := &pb.ListRequest{}
+	for _, s := range listStates {
+		state, err := parseState(s)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		req.States = append(req.States, state)
+	}
+
+	c := Client.New(addr)
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp, err := c.ListCrawls(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to list crawls: %s\n", err.Error())
+		return
+	}
+
+	crawls := []crawlSummary{}
+	for _, s := range resp.Crawls {
+		summary := crawlSummary{
+			URL:     s.URL,
+			State:   s.Status.String(),
+			Fetched: int(s.Fetched),
+			Queued:  int(s.Queued),
+			Errors:  int(s.Errors),
+		}
+		if s.StartedMillis != 0 {
+			t := time.Unix(0, s.StartedMillis*int64(time.Millisecond))
+			summary.Started = &t
+		}
+		crawls = append(crawls, summary)
+	}
+	sort.SliceStable(crawls, func(i, j int) bool { return less(crawls[i], crawls[j]) })
+
+	if listOutput == outputJSON {
+		if err := printJSON(os.Stdout, crawls); err != nil {
+			fmt.Println(err)
+		}
+		return
+	}
+	rows := [][]string{}
+	for _, s := range crawls {
+		started := "-"
+		if s.Started != nil {
+			started = s.Started.Format("2006-01-02 15:04:05")
+		}
+		rows = append(rows, []string{
+			s.URL, s.State, started,
+			strconv.Itoa(s.Fetched), strconv.Itoa(s.Queued), strconv.Itoa(s.Errors),
+		})
+	}
+	printTable(os.Stdout, []string{"URL", "STATE", "STARTED", "FETCHED", "QUEUED", "ERRORS"}, rows)
+}
+
+// started is when a crawl started, or the zero time if it hasn't.
+func started(s crawlSummary) time.Time {
+	if s.Started == nil {
+		return time.Time{}
+	}
+	return *s.Started
+}
+
+// parseState turns a state name as typed on the command line into a
+// crawl state; "limit-reached", "limit_reached" and "LIMIT_REACHED"
+// are all fine.
+func parseState(s string) (pb.URLState_Status, error) {
+	name := strings.ToUpper(strings.Replace(strings.Replace(s, "-", "_", -1), " ", "_", -1))
+	state, ok := pb.URLState_Status_value[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown state %q", s)
+	}
+	return pb.URLState_Status(state), nil
+}
+
+func init() {
+	rootCmd.AddCommand(listCmd)
+	listCmd.Flags().StringSliceVar(&listStates, "state", nil, "only list crawls in this state (may be repeated)")
+	listCmd.Flags().StringVar(&listSort, "sort", "url", "sort by url, state, started, fetched, queued, or errors")
+	listCmd.Flags().StringVarP(&listOutput, "output", "o", outputTable, "output format: table or json")
+}
@@ -0,0 +1,156 @@
+// Copyright © 2018 Joe McMahon <joe.mcmahon@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/joemcmahon/joe_macmahon_technical_test/api/client"
+	"google.golang.org/grpc/status"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Output formats shared by the commands that print reports.
+const (
+	outputTable = "table"
+	outputTree  = "tree"
+	outputCSV   = "csv"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// checkOutput makes sure an --output value is one of those allowed.
+func checkOutput(output string, allowed ...string) error {
+	for _, a := range allowed {
+		if output == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown output format %q (use %s)", output, strings.Join(allowed, ", "))
+}
+
+// printTable writes rows as aligned columns under a header.
+func printTable(w io.Writer, header []string, rows [][]string) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	tw.Flush()
+}
+
+// printJSON writes v as indented JSON.
+func printJSON(w io.Writer, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// printCSV writes rows as CSV under a header.
+func printCSV(w io.Writer, header []string, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// printYAML writes v as YAML.
+func printYAML(w io.Writer, v interface{}) error {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// report is what a report command found, ready to print: data is
+// printed as JSON, and rows under header as a table or CSV. none is
+// printed instead of an empty table. A report whose table isn't just
+// rows prints it with table instead.
+type report struct {
+	data   interface{}
+	header []string
+	rows   [][]string
+	none   string
+	table  func(w io.Writer)
+}
+
+// runReport does what every report command does: checks the --output
+// format against the ones it allows, asks the server for the report
+// with get, and prints it. what names the report in errors.
+func runReport(output, what string, allowed []string, get func(context.Context, *Client.CrawlClient) (*report, error)) {
+	if err := checkOutput(output, allowed...); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	c := Client.New(addr)
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	r, err := get(ctx, c)
+	if err != nil {
+		if s, ok := status.FromError(err); ok {
+			// The server is telling us why there's no report.
+			fmt.Println(s.Message())
+			return
+		}
+		fmt.Printf("Failed to get %s: %s\n", what, err.Error())
+		return
+	}
+	if err := r.print(os.Stdout, output); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// print writes the report in an output format.
+func (r *report) print(w io.Writer, output string) error {
+	switch {
+	case output == outputJSON:
+		return printJSON(w, r.data)
+	case output == outputCSV:
+		return printCSV(w, r.header, r.rows)
+	case r.table != nil:
+		r.table(w)
+	case len(r.rows) == 0:
+		fmt.Fprintln(w, r.none)
+	default:
+		printTable(w, r.header, r.rows)
+	}
+	return nil
+}
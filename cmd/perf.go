@@ -0,0 +1,178 @@
+// Copyright © 2018 Joe McMahon <joe.mcmahon@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/joemcmahon/joe_macmahon_technical_test/api/client"
+	pb "github.com/joemcmahon/joe_macmahon_technical_test/api/crawl"
+	"github.com/spf13/cobra"
+)
+
+const perfUsage = `Usage: crawl perf <url>
+
+Shows how quickly the pages in the crawl of <url> came back, and lists
+the slowest and heaviest of them.`
+
+// perfCmd represents the perf command
+var perfCmd = &cobra.Command{
+	Use:   "perf",
+	Short: "Show how fast a crawl's pages were, and the slowest and heaviest",
+	Long: `Shows the time to first byte and total fetch time of the pages in
+a crawl (median, 95th percentile and worst) and the total size of
+their bodies, then lists the slowest and heaviest pages.
+
+  --top=N        how many of the slowest and heaviest pages to list
+                 (default 10)
+  --output=FMT   table (the default) or json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 1 {
+			fmt.Println(perfUsage)
+			return
+		}
+		perf(args[0])
+	},
+}
+
+var (
+	perfTop    int32
+	perfOutput string
+)
+
+// perfSummary is a crawl's timings as printed in JSON.
+type perfSummary struct {
+	Pages              int32 `json:"pages"`
+	FirstByteP50Millis int64 `json:"firstByteP50Millis"`
+	FirstByteP95Millis int64 `json:"firstByteP95Millis"`
+	FirstByteMaxMillis int64 `json:"firstByteMaxMillis"`
+	FetchP50Millis     int64 `json:"fetchP50Millis"`
+	FetchP95Millis     int64 `json:"fetchP95Millis"`
+	FetchMaxMillis     int64 `json:"fetchMaxMillis"`
+	TotalBytes         int64 `json:"totalBytes"`
+}
+
+// pagePerf is one page's timings as printed in JSON.
+type pagePerf struct {
+	URL             string `json:"url"`
+	Status          int32  `json:"status,omitempty"`
+	FirstByteMillis int64  `json:"firstByteMillis"`
+	FetchMillis     int64  `json:"fetchMillis"`
+	Size            int64  `json:"size"`
+}
+
+func perf(url string) {
+	runReport(perfOutput, "timings", []string{outputTable, outputJSON}, func(ctx context.Context, c *Client.CrawlClient) (*report, error) {
+		found, err := c.Perf(ctx, &pb.URLRequest{URL: url, Top: perfTop})
+		if err != nil {
+			return nil, err
+		}
+		return perfReport(found), nil
+	})
+}
+
+// perfReport renders a crawl's timings. The table is the summary,
+// then the slowest and heaviest pages.
+func perfReport(found *pb.PerfReport) *report {
+	data := struct {
+		Summary  perfSummary `json:"summary"`
+		Slowest  []pagePerf  `json:"slowest"`
+		Heaviest []pagePerf  `json:"heaviest"`
+	}{pagePerfSummary(found.Summary), pagePerfs(found.Slowest), pagePerfs(found.Heaviest)}
+	return &report{data: data, table: func(w io.Writer) {
+		if found.Summary == nil || found.Summary.Pages == 0 {
+			fmt.Fprintln(w, "No pages fetched yet")
+			return
+		}
+		header := []string{"URL", "STATUS", "FETCH", "FIRST BYTE", "SIZE"}
+		printPerf(w, found.Summary)
+		fmt.Fprintln(w, "\nSlowest pages:")
+		printTable(w, header, perfRows(found.Slowest))
+		fmt.Fprintln(w, "\nHeaviest pages:")
+		printTable(w, header, perfRows(found.Heaviest))
+	}}
+}
+
+// printPerf prints a crawl's timings; crawl status uses it too.
+func printPerf(w io.Writer, p *pb.CrawlPerf) {
+	fmt.Fprintf(w, "%d pages fetched, %d bytes in all\n", p.Pages, p.TotalBytes)
+	printTable(w, []string{"", "P50", "P95", "MAX"}, [][]string{
+		{"first byte", millis(p.FirstByteP50Millis), millis(p.FirstByteP95Millis), millis(p.FirstByteMaxMillis)},
+		{"fetch", millis(p.FetchP50Millis), millis(p.FetchP95Millis), millis(p.FetchMaxMillis)},
+	})
+}
+
+func perfRows(pages []*pb.PagePerf) [][]string {
+	rows := [][]string{}
+	for _, p := range pages {
+		status := ""
+		if p.HttpStatus != 0 {
+			status = strconv.Itoa(int(p.HttpStatus))
+		}
+		rows = append(rows, []string{p.URL, status, millis(p.FetchMillis), millis(p.FirstByteMillis), strconv.FormatInt(p.Size, 10)})
+	}
+	return rows
+}
+
+func pagePerfSummary(p *pb.CrawlPerf) perfSummary {
+	if p == nil {
+		return perfSummary{}
+	}
+	return perfSummary{
+		Pages:              p.Pages,
+		FirstByteP50Millis: p.FirstByteP50Millis,
+		FirstByteP95Millis: p.FirstByteP95Millis,
+		FirstByteMaxMillis: p.FirstByteMaxMillis,
+		FetchP50Millis:     p.FetchP50Millis,
+		FetchP95Millis:     p.FetchP95Millis,
+		FetchMaxMillis:     p.FetchMaxMillis,
+		TotalBytes:         p.TotalBytes,
+	}
+}
+
+func pagePerfs(pages []*pb.PagePerf) []pagePerf {
+	out := []pagePerf{}
+	for _, p := range pages {
+		out = append(out, pagePerf{
+			URL:             p.URL,
+			Status:          p.HttpStatus,
+			FirstByteMillis: p.FirstByteMillis,
+			FetchMillis:     p.FetchMillis,
+			Size:            p.Size,
+		})
+	}
+	return out
+}
+
+// millis prints a count of milliseconds as a duration.
+func millis(ms int64) string {
+	return (time.Duration(ms) * time.Millisecond).String()
+}
+
+func init() {
+	rootCmd.AddCommand(perfCmd)
+	perfCmd.Flags().Int32Var(&perfTop, "top", 0, "how many of the slowest and heaviest pages to list (default 10)")
+	perfCmd.Flags().StringVarP(&perfOutput, "output", "o", outputTable, "output format: table or json")
+}
@@ -0,0 +1,118 @@
+// Copyright © 2018 Joe McMahon <joe.mcmahon@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/joemcmahon/joe_macmahon_technical_test/api/client"
+	pb "github.com/joemcmahon/joe_macmahon_technical_test/api/crawl"
+	"github.com/spf13/cobra"
+)
+
+const redirectsUsage = `Usage: crawl redirects <url>
+
+Lists the URLs in the crawl of <url> that redirect more than once.`
+
+// redirectsCmd represents the redirects command
+var redirectsCmd = &cobra.Command{
+	Use:   "redirects",
+	Short: "List the redirect chains in a crawl",
+	Long: `Lists every URL in a crawl whose redirects took more than one hop
+before getting to a page, or that redirected in a loop or too many
+times to follow, with each hop in the chain. Links to these URLs are
+worth pointing straight at where they end up.
+
+  --output=FMT   table (the default), csv, or json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 1 {
+			fmt.Println(redirectsUsage)
+			return
+		}
+		redirects(args[0])
+	},
+}
+
+var redirectsOutput string
+
+// redirectChain is a chain of redirects as printed in JSON.
+type redirectChain struct {
+	URL        string       `json:"url"`
+	Hops       []redirect   `json:"hops"`
+	FinalURL   string       `json:"finalURL"`
+	Outcome    string       `json:"outcome"`
+	Error      string       `json:"error,omitempty"`
+	LinkedFrom []linkSource `json:"linkedFrom"`
+}
+
+func redirects(url string) {
+	runReport(redirectsOutput, "redirects", []string{outputTable, outputCSV, outputJSON}, func(ctx context.Context, c *Client.CrawlClient) (*report, error) {
+		found, err := c.RedirectChains(ctx, &pb.URLRequest{URL: url})
+		if err != nil {
+			return nil, err
+		}
+		return redirectsReport(found), nil
+	})
+}
+
+// redirectsReport renders the redirect chains the server found.
+func redirectsReport(found *pb.RedirectReport) *report {
+	chains := []redirectChain{}
+	for _, r := range found.Chains {
+		chain := redirectChain{
+			URL:        r.URL,
+			Hops:       redirectsFor(r.Hops),
+			FinalURL:   r.FinalURL,
+			Outcome:    outcomeName(r.Outcome),
+			Error:      r.Error,
+			LinkedFrom: []linkSource{},
+		}
+		for _, from := range r.LinkedFrom {
+			chain.LinkedFrom = append(chain.LinkedFrom, linkSource{Source: from.Source, Text: from.Text})
+		}
+		chains = append(chains, chain)
+	}
+
+	// One row for each hop; the error, if any, goes on the last.
+	rows := [][]string{}
+	for _, chain := range chains {
+		for i, hop := range chain.Hops {
+			errText := ""
+			if i == len(chain.Hops)-1 {
+				errText = chain.Error
+			}
+			rows = append(rows, []string{chain.URL, strconv.Itoa(i + 1), strconv.Itoa(hop.Status), hop.Location, errText})
+		}
+	}
+	return &report{
+		data:   chains,
+		header: []string{"URL", "HOP", "STATUS", "LOCATION", "ERROR"},
+		rows:   rows,
+		none:   "No redirect chains found",
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(redirectsCmd)
+	redirectsCmd.Flags().StringVarP(&redirectsOutput, "output", "o", outputTable, "output format: table, csv, or json")
+}
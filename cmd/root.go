@@ -32,40 +32,79 @@ import (
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"google.golang.org/grpc/status"
 )
 
 // Global constants for all commands
 const addr = "127.0.0.1:10000"
 
 // Global functions for all commands
-func send(args []string, usage string, what pb.URLRequestCommand, action string) {
+
+// send fills in the URL from the command line and sends the request
+// to the server. Any other settings are expected to already be in req.
+func send(args []string, usage string, req *pb.URLRequest, action string) {
 	if len(args) == 0 {
 		fmt.Println(usage)
 		return
 	}
-	url := args[0]
+	req.URL = args[0]
 
 	c := Client.New(addr)
 	defer c.Close()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	req := pb.URLRequest{URL: url, State: what}
-	state, err := c.CrawlSite(ctx, &req)
+	state, err := c.CrawlSite(ctx, req)
 	if err != nil {
-		fmt.Println("Failed to %s crawl: %s", action, err.Error())
+		fmt.Printf("Failed to %s crawl: %s\n", action, err.Error())
 		return
 	}
 	fmt.Println(state.Status.String(), state.Message)
+	if state.Perf != nil {
+		printPerf(os.Stdout, state.Perf)
+	}
+	if len(state.Frontier) > 0 {
+		fmt.Printf("%d URLs found but not crawled:\n", len(state.Frontier))
+		for _, u := range state.Frontier {
+			fmt.Println("  ", u)
+		}
+	}
 }
 
-func format(args []string, usage string) {
+// format gets the crawl tree for the URL on the command line and
+// prints it in the requested output format.
+func format(args []string, usage string, output string) {
 	if len(args) == 0 {
 		fmt.Println(usage)
 		return
 	}
-	url := args[0]
+	if err := checkOutput(output, outputTree, outputJSON, outputYAML, outputCSV); err != nil {
+		fmt.Println(err)
+		return
+	}
+	root := result(args[0])
+	if root == nil {
+		return
+	}
+	var err error
+	switch output {
+	case outputJSON:
+		err = printJSON(os.Stdout, siteEntryFor(root))
+	case outputYAML:
+		err = printYAML(os.Stdout, siteEntryFor(root))
+	case outputCSV:
+		err = printCSV(os.Stdout, siteHeader, siteRows(root))
+	default:
+		fmt.Print(formatTree(root))
+	}
+	if err != nil {
+		fmt.Println(err)
+	}
+}
 
+// result gets the crawl tree for url from the server. If there
+// isn't one, it says why and returns nil.
+func result(url string) *pb.SiteNode {
 	c := Client.New(addr)
 	defer c.Close()
 
@@ -74,20 +113,40 @@ func format(args []string, usage string) {
 	req := pb.URLRequest{URL: url, State: pb.URLRequest_SHOW}
 	stream, err := c.CrawlResult(ctx, &req)
 	if err != nil {
-		fmt.Println("Failed to open stream: %s", err.Error())
-		return
+		fmt.Printf("Failed to open stream: %s\n", err.Error())
+		return nil
 	}
+	// The nodes come a node at a time, each after its parent;
+	// parents[n] is the last node we saw at level n.
+	var root *pb.SiteNode
+	parents := []*pb.SiteNode{}
 	for {
 		resp, err := stream.Recv()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			fmt.Println("Can't read server stream: %s", err.Error())
-			return
+			if s, ok := status.FromError(err); ok {
+				// The server is telling us why there's nothing to show.
+				fmt.Println(s.Message())
+				return nil
+			}
+			fmt.Printf("Can't read server stream: %s\n", err.Error())
+			return nil
+		}
+		level := int(resp.Level)
+		if level == 0 {
+			root = resp
+		} else if level <= len(parents) {
+			parent := parents[level-1]
+			parent.Children = append(parent.Children, resp)
+		} else {
+			fmt.Printf("Can't read server stream: %s arrived without its parent\n", resp.SiteURL)
+			return nil
 		}
-		fmt.Println(resp.TreeString)
+		parents = append(parents[:level], resp)
 	}
+	return root
 }
 
 var cfgFile string
@@ -25,7 +25,7 @@ import (
 )
 
 // showCmd represents the show command
-const showUsage = `Usage: crawl show <url>
+const showUsage = `Usage: crawl show [--format=FMT] <url>
 
 Shows the formatted results of the crawl so far.`
 
@@ -33,14 +33,20 @@ var showCmd = &cobra.Command{
 	Use:   "show",
 	Short: "Show the crawl status and results for a URL",
 	Long: `Displays the current status of the crawl (stopped, running,
-complete) and displays the crawl tree if any.`,
+complete) and displays the crawl tree if any.
+
+  --format=FMT   tree (the default), json or yaml for the tree as nested
+                 data, or csv for one row per URL`,
 	Run: func(cmd *cobra.Command, args []string) {
-		format(args, showUsage)
+		format(args, showUsage, showFormat)
 	},
 }
 
+var showFormat string
+
 func init() {
 	rootCmd.AddCommand(showCmd)
+	showCmd.Flags().StringVarP(&showFormat, "format", "f", outputTree, "output format: tree, json, yaml, or csv")
 
 	// Here you will define your flags and configuration settings.
 
@@ -21,24 +21,141 @@
 package cmd
 
 import (
+	"fmt"
+	"time"
+
 	pb "github.com/joemcmahon/joe_macmahon_technical_test/api/crawl"
 	"github.com/spf13/cobra"
 )
 
-const startUsage = `Usage client start <url>
+const startUsage = `Usage client start [--workers=N] [--rate=R --burst=B --min-delay=D]
+                    [--ignore-robots] [--sitemaps]
+                    [--max-depth=N] [--max-pages=N] [--timeout=D]
+                    [--include=PATTERN ...] [--exclude=PATTERN ...]
+                    [--hosts=exact|subdomains|domain] [--alias=HOST ...]
+                    [--any-scheme] [--assets]
+                    [--normalize=default|safe|usually-safe|unsafe]
+                    [--strip-param=NAME ...] [--sort-query]
+                    [--lowercase-paths] [--keep-trailing-slash] <url>
 
 Starts a crawl on the supplied URL; the URL is required.
 `
 
+// Per-crawl settings for the start command.
+var (
+	workers      int32
+	rate         float64
+	burst        int32
+	minDelay     time.Duration
+	ignoreRobots bool
+	sitemaps     bool
+	maxDepth     int32
+	maxPages     int32
+	timeout      time.Duration
+	include      []string
+	exclude      []string
+	hosts        string
+	aliases      []string
+	anyScheme    bool
+	assets       bool
+	normalize    string
+	stripParams  []string
+	sortQuery    bool
+	lowercase    bool
+	keepSlash    bool
+)
+
+// The --hosts settings.
+var hostPolicies = map[string]pb.URLRequest_HostPolicy{
+	"exact":      pb.URLRequest_EXACT_HOST,
+	"subdomains": pb.URLRequest_SUBDOMAINS,
+	"domain":     pb.URLRequest_REGISTRABLE_DOMAIN,
+}
+
+// The --normalize settings.
+var normalizeFlags = map[string]pb.URLRequest_NormalizeFlags{
+	"default":      pb.URLRequest_DEFAULT_FLAGS,
+	"safe":         pb.URLRequest_SAFE_FLAGS,
+	"usually-safe": pb.URLRequest_USUALLY_SAFE_FLAGS,
+	"unsafe":       pb.URLRequest_UNSAFE_FLAGS,
+}
+
 // startCmd represents the start command
 var startCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Start crawling a URL",
 	Long: `Adds the URL to the crawl list and starts crawling it. The
 crawl will continue until all URLS in this URL's domain reachable from
-this root URL are visited, or the crawl is explicitly stopped.`,
+this root URL are visited, the crawl is explicitly stopped, or it
+reaches one of the limits set with --max-depth, --max-pages, or
+--timeout.
+
+--include and --exclude limit the crawl to part of the site. Patterns
+are matched against the path and query of each URL: a glob, where *
+matches anything ("/tag/*", "/search?*"), or a regular expression
+prefixed with "re:" ("re:^/admin"). URLs that don't match an --include
+pattern, or that match an --exclude pattern, are reported as out of
+scope instead of being crawled.
+
+By default only URLs on the root URL's host, with its scheme, are part
+of the site; everything else is offsite. --hosts=subdomains adds every
+host under the root's host, and --hosts=domain every host in the same
+registrable domain (so www.example.co.uk takes in shop.example.co.uk).
+--alias adds other hosts that are the same site, and --any-scheme
+treats http and https as the same.
+
+URLs are normalized before they're crawled, so that the different ways
+of writing a page's URL are only crawled once. --normalize picks how
+far that goes: safe only fixes the case of the scheme and host, escapes,
+and default ports; usually-safe also removes "." and ".." segments;
+unsafe also removes index.html, "www." and doubled slashes, and sorts
+the query. The default is like unsafe, but leaves index.html and "www."
+alone. --strip-param removes a query parameter, such as a tracking or
+session id ("utm_*" removes every parameter starting "utm_"),
+--sort-query sorts the query whatever the --normalize setting, and
+--lowercase-paths is for sites where case doesn't matter. Every page's
+path gets a trailing slash, unless it ends in a file name ("page.html",
+"report.pdf", but not "v1.2") or --keep-trailing-slash is given, in
+which case "/a" and "/a/" are different pages.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		send(args, startUsage, pb.URLRequest_START, "start")
+		policy, ok := hostPolicies[hosts]
+		if !ok {
+			fmt.Printf("unknown --hosts setting %q (use exact, subdomains, or domain)\n", hosts)
+			return
+		}
+		flags, ok := normalizeFlags[normalize]
+		if !ok {
+			fmt.Printf("unknown --normalize setting %q (use default, safe, usually-safe, or unsafe)\n", normalize)
+			return
+		}
+		req := pb.URLRequest{
+			State:             pb.URLRequest_START,
+			Workers:           workers,
+			RequestsPerSecond: rate,
+			Burst:             burst,
+			MinDelayMillis:    int64(minDelay / time.Millisecond),
+			IgnoreRobots:      ignoreRobots,
+			Sitemaps:          sitemaps,
+			MaxDepth:          maxDepth,
+			MaxPages:          maxPages,
+			TimeoutSeconds:    int64((timeout + time.Second - 1) / time.Second),
+			Include:           include,
+			Exclude:           exclude,
+			HostPolicy:        policy,
+			HostAliases:       aliases,
+			AnyScheme:         anyScheme,
+			Assets:            assets,
+			NormalizeFlags:    flags,
+			StripParams:       stripParams,
+			SortQuery:         sortQuery,
+			LowercasePaths:    lowercase,
+			KeepTrailingSlash: keepSlash,
+		}
+		// Zero is a setting too: --rate=0 turns the server's rate limit off.
+		req.RequestsPerSecondSet = cmd.Flags().Changed("rate")
+		req.BurstSet = cmd.Flags().Changed("burst")
+		req.MinDelaySet = cmd.Flags().Changed("min-delay")
+		send(args, startUsage, &req, "start")
 	},
 }
 
@@ -54,4 +171,25 @@ func init() {
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
 	// startCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	startCmd.Flags().Int32Var(&workers, "workers", 0, "Number of pages to fetch in parallel (default: server's setting)")
+	startCmd.Flags().Float64Var(&rate, "rate", 0, "Maximum requests per second to any one host; 0 for no limit (default: server's setting)")
+	startCmd.Flags().Int32Var(&burst, "burst", 0, "Requests to a host that may be made at once (default: server's setting)")
+	startCmd.Flags().DurationVar(&minDelay, "min-delay", 0, "Minimum delay between requests to the same host; 0 for none (default: server's setting)")
+	startCmd.Flags().BoolVar(&ignoreRobots, "ignore-robots", false, "Crawl pages even if robots.txt disallows them (only for sites you own)")
+	startCmd.Flags().BoolVar(&sitemaps, "sitemaps", false, "Also crawl the pages in the site's sitemaps, and report orphaned pages")
+	startCmd.Flags().Int32Var(&maxDepth, "max-depth", 0, "Most links to follow away from the root URL (default: no limit)")
+	startCmd.Flags().Int32Var(&maxPages, "max-pages", 0, "Most pages to fetch (default: no limit)")
+	startCmd.Flags().DurationVar(&timeout, "timeout", 0, "Longest time to crawl for (default: no limit)")
+	// StringArray, not StringSlice: regular expressions may have commas.
+	startCmd.Flags().StringArrayVar(&include, "include", nil, "Only crawl URLs whose path and query match this pattern (may be repeated)")
+	startCmd.Flags().StringArrayVar(&exclude, "exclude", nil, "Don't crawl URLs whose path and query match this pattern (may be repeated)")
+	startCmd.Flags().StringVar(&hosts, "hosts", "exact", "Hosts that are part of the site: exact, subdomains, or domain")
+	startCmd.Flags().StringSliceVar(&aliases, "alias", nil, "Another host that is the same site (may be repeated)")
+	startCmd.Flags().BoolVar(&anyScheme, "any-scheme", false, "Treat http and https as the same site")
+	startCmd.Flags().BoolVar(&assets, "assets", false, "Also check the images, scripts, stylesheets and media pages use")
+	startCmd.Flags().StringVar(&normalize, "normalize", "default", "How far to normalize URLs: default, safe, usually-safe, or unsafe")
+	startCmd.Flags().StringSliceVar(&stripParams, "strip-param", nil, "A query parameter to remove from URLs; \"utm_*\" matches a prefix (may be repeated)")
+	startCmd.Flags().BoolVar(&sortQuery, "sort-query", false, "Sort the query parameters in URLs")
+	startCmd.Flags().BoolVar(&lowercase, "lowercase-paths", false, "Lowercase the paths of URLs, for sites where case doesn't matter")
+	startCmd.Flags().BoolVar(&keepSlash, "keep-trailing-slash", false, "Treat /a and /a/ as different pages")
 }
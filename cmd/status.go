@@ -32,11 +32,11 @@ Returns the status of the crawl for this URL.`
 // statusCmd represents the status command
 var statusCmd = &cobra.Command{
 	Use:   "status",
-	Short: "Show status of all crawls",
-	Long: `Lists all crawls currently added and their status (stopped,
-crawling, or complete).`,
+	Short: "Show the status of a crawl",
+	Long: `Shows the status of the crawl for a URL (stopped, running,
+done, failed, or limit reached). Use crawl list to see every crawl.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		send(args, statusUsage, pb.URLRequest_CHECK, "status")
+		send(args, statusUsage, &pb.URLRequest{State: pb.URLRequest_CHECK}, "status")
 	},
 }
 
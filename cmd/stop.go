@@ -37,7 +37,7 @@ var stopCmd = &cobra.Command{
 	Long: `Tells the crawler to stop crawling URLs for the specified
 base URL. No-op if the URL is not being crawled.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		send(args, stopUsage, pb.URLRequest_STOP, "stop")
+		send(args, stopUsage, &pb.URLRequest{State: pb.URLRequest_STOP}, "stop")
 	},
 }
 
@@ -0,0 +1,103 @@
+// Copyright © 2018 Joe McMahon <joe.mcmahon@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/joemcmahon/joe_macmahon_technical_test/api/client"
+	pb "github.com/joemcmahon/joe_macmahon_technical_test/api/crawl"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+)
+
+const watchUsage = `Usage: crawl watch <url>
+
+Shows what the crawl of <url> is doing as it happens.`
+
+// watchCmd represents the watch command
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Follow a crawl as it happens",
+	Long: `Prints the crawl's events as they happen: URLs queued, pages
+fetched or failed, and changes of state. Stops when the crawl is done.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		watch(args, watchUsage)
+	},
+}
+
+func watch(args []string, usage string) {
+	if len(args) == 0 {
+		fmt.Println(usage)
+		return
+	}
+
+	c := Client.New(addr)
+	defer c.Close()
+
+	// No timeout: we watch until the crawl is over, or we're interrupted.
+	req := pb.URLRequest{URL: args[0]}
+	stream, err := c.WatchCrawl(context.Background(), &req)
+	if err != nil {
+		fmt.Printf("Failed to open stream: %s\n", err.Error())
+		return
+	}
+	for {
+		e, err := stream.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			if s, ok := status.FromError(err); ok {
+				fmt.Println(s.Message())
+				return
+			}
+			fmt.Printf("Can't read server stream: %s\n", err.Error())
+			return
+		}
+		fmt.Println(formatEvent(e))
+	}
+}
+
+// formatEvent renders an event as a line of text.
+func formatEvent(e *pb.CrawlEvent) string {
+	when := time.Unix(0, e.TimeMillis*int64(time.Millisecond)).Format("15:04:05.000")
+	elapsed := time.Duration(e.ElapsedMillis) * time.Millisecond
+	switch e.Kind {
+	case pb.CrawlEvent_QUEUED:
+		return fmt.Sprintf("%s queued  %s", when, e.URL)
+	case pb.CrawlEvent_FETCHED:
+		if e.HttpStatus != 0 {
+			return fmt.Sprintf("%s fetched %s %d (%v)", when, e.URL, e.HttpStatus, elapsed)
+		}
+		return fmt.Sprintf("%s fetched %s (%v)", when, e.URL, elapsed)
+	case pb.CrawlEvent_FAILED:
+		return fmt.Sprintf("%s failed  %s (%v): %s", when, e.URL, elapsed, e.Error)
+	}
+	return fmt.Sprintf("%s crawl is %s", when, e.State)
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+}
@@ -0,0 +1,88 @@
+package crawler
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/joemcmahon/joe_macmahon_technical_test/crawler/page"
+)
+
+// Problem is something an audit found wrong with a page.
+type Problem string
+
+// The problems an audit looks for.
+const (
+	MissingTitle       Problem = "missing title"
+	DuplicateTitle     Problem = "duplicate title"
+	MissingDescription Problem = "missing description"
+	MultipleH1s        Problem = "multiple h1s"
+	// ThinPage is a page with fewer than ThinWords words.
+	ThinPage Problem = "thin page"
+)
+
+// ThinWords is the fewest words a page can have without being thin.
+const ThinWords = 300
+
+// AuditedPage is a page an audit found problems with.
+type AuditedPage struct {
+	URL      string
+	Meta     page.Meta
+	Problems []Problem
+	// SameTitle is the other pages with the same title, for a
+	// DuplicateTitle.
+	SameTitle []string
+}
+
+// Audit checks what every HTML page the crawl fetched says about
+// itself, and returns the pages with problems, sorted by URL.
+// Duplicates aren't checked; they're the same as pages that are.
+func (state *State) Audit() []AuditedPage {
+	state.Lock()
+	pages := map[string]page.Meta{}
+	for URL, visit := range state.cache {
+		res := visit.Result
+		if visit.Outcome != Fetched || visit.Asset != "" || res == nil || res.Meta == nil || !strings.Contains(res.ContentType, "html") {
+			continue
+		}
+		pages[URL] = *res.Meta
+	}
+	state.Unlock()
+
+	titles := map[string][]string{}
+	for URL, meta := range pages {
+		if meta.Title != "" {
+			titles[meta.Title] = append(titles[meta.Title], URL)
+		}
+	}
+
+	audited := []AuditedPage{}
+	for URL, meta := range pages {
+		a := AuditedPage{URL: URL, Meta: meta}
+		if meta.Title == "" {
+			a.Problems = append(a.Problems, MissingTitle)
+		}
+		if same := titles[meta.Title]; meta.Title != "" && len(same) > 1 {
+			a.Problems = append(a.Problems, DuplicateTitle)
+			for _, other := range same {
+				if other != URL {
+					a.SameTitle = append(a.SameTitle, other)
+				}
+			}
+			sort.Strings(a.SameTitle)
+		}
+		if meta.Description == "" {
+			a.Problems = append(a.Problems, MissingDescription)
+		}
+		if len(meta.H1s) > 1 {
+			a.Problems = append(a.Problems, MultipleH1s)
+		}
+		if meta.Words < ThinWords {
+			a.Problems = append(a.Problems, ThinPage)
+		}
+		if len(a.Problems) > 0 {
+			audited = append(audited, a)
+		}
+	}
+	sort.Slice(audited, func(i, j int) bool { return audited[i].URL < audited[j].URL })
+	return audited
+}
@@ -1,15 +1,15 @@
 package crawler
 
 import (
-	"errors"
+	"fmt"
 	"net/url"
 	"os"
+	"sort"
 	"sync"
 	"time"
 
-	"github.com/PuerkitoBio/purell"
-	"github.com/disiqueira/gotree"
 	"github.com/golang-collections/go-datastructures/queue"
+	"github.com/joemcmahon/joe_macmahon_technical_test/crawler/page"
 	sharedTree "github.com/joemcmahon/joe_macmahon_technical_test/crawler/shared-tree"
 	log "github.com/sirupsen/logrus"
 )
@@ -21,60 +21,197 @@ func init() {
 }
 
 type unprocessedItem struct {
-	insertPoint *gotree.Tree
-	URL         string
+	// The page the URL was found on; empty for the root.
+	source string
+	URL    string
+	// The anchor text of the link to the URL.
+	text string
+	// The URL came from the site's sitemap rather than a link.
+	fromSitemap bool
+	// Number of links between the root and this URL. An asset is
+	// at the depth of the page it's on.
+	depth int
+	// The kind of asset the URL is; empty for a page.
+	asset page.Asset
 }
 
 type controlFunc func()
 
+// Options controls how a crawl is run. An empty Options is a usable
+// configuration: it gets the default number of workers and no
+// politeness limits.
+type Options struct {
+	// Workers is the number of pages fetched concurrently.
+	Workers int
+	// Politeness limits the requests made to each host.
+	Politeness Politeness
+	// Limiter applies the Politeness limits. Crawls sharing a Limiter
+	// share limits for the same host; if nil, the crawl gets its own.
+	Limiter *HostLimiter `json:"-"`
+	// IgnoreRobots skips the robots.txt checks; only for sites we own.
+	IgnoreRobots bool
+	// Sitemaps seeds the crawl with every page in the site's sitemaps.
+	Sitemaps bool
+	// Limits can stop the crawl before the whole site has been crawled.
+	Limits Limits
+	// Patterns limit the crawl to part of the site.
+	Patterns Patterns
+	// Scope says which hosts are part of the site.
+	Scope Scope
+	// Assets checks the images, scripts, stylesheets and so on that
+	// pages use, as well as the pages they link to.
+	Assets bool
+	// Normalization says how URLs are normalized.
+	Normalization Normalization
+}
+
+// Limits stop a crawl before it has exhausted the site. Zero means no limit.
+type Limits struct {
+	// MaxDepth is the most links we follow away from the root.
+	MaxDepth int
+	// MaxPages is the most pages we fetch.
+	MaxPages int
+	// Timeout is how long the crawl may run, including any time paused.
+	Timeout time.Duration
+}
+
+// DefaultWorkers is the number of workers a crawl gets if none is specified.
+const DefaultWorkers = 4
+
 // State is the current state of the crawler.
 type State struct {
-	BaseURL     string
-	domain      string
-	cache       map[string]error
-	tree        *sharedTree.Tree
-	fetcher     Fetcher
-	debug       bool
-	Done        bool
-	unprocessed *queue.Queue
-	Start       controlFunc
-	Pause       controlFunc
-	Resume      controlFunc
-	Wait        controlFunc
-	Quit        controlFunc
+	BaseURL       string
+	root          string // the URL as given to New
+	scope         Scope
+	site          *site // the scope, applied to the root URL
+	cache         map[string]Visit
+	tree          *sharedTree.Tree
+	fetcher       Fetcher
+	debug         bool
+	Done          bool
+	unprocessed   *queue.Queue
+	workers       int
+	inFlight      int
+	active        map[int]unprocessedItem // taken off the queue, not finished
+	nextActive    int
+	politeness    Politeness
+	limiter       *HostLimiter
+	limiterID     int // the id the crawl waits on the limiter with
+	ignoreRobots  bool
+	sitemaps      bool
+	assets        bool
+	linked        map[string]bool
+	sitemapped    map[string]bool
+	anchorLinks   map[AnchorLink]bool // links with a fragment
+	hashes        map[string]string   // content hash to the first page with it
+	limits        Limits
+	patterns      Patterns
+	matcher       *matcher // the compiled patterns
+	normalization Normalization
+	started       time.Time
+	deadline      *time.Timer // ends the crawl when Limits.Timeout runs out
+	expired       bool        // the crawl ran out of time
+	fetched       int
+	frontier      map[string]bool // found, but not fetched because of a limit
+	Limit         string          // the limit that ended the crawl, if any
+	queued        map[string]bool // every URL ever put on the queue
+	watchers      watchers
+	Start         controlFunc
+	Pause         controlFunc
+	Resume        controlFunc
+	Wait          controlFunc
+	Quit          controlFunc
 
 	sync.Mutex
 }
 
 // Fetcher defines an interface that can fetch URLs.
 type Fetcher interface {
-	// Fetch returns the body of URL and
-	// a slice of URLs found on that page.
-	Fetch(url string) (body string, urls []string, err error)
+	// Fetch fetches URL, and returns what happened: the page's
+	// body and links if it worked, and the error if it didn't.
+	// It never returns nil.
+	Fetch(url string) *page.Result
 }
 
-const queueSize = 200
+// ScopedFetcher is implemented by Fetchers that can be told which
+// redirects to follow.
+type ScopedFetcher interface {
+	// FetchWithin fetches URL like Fetch, but only follows a redirect
+	// if follow says the URL it goes to may be fetched. A redirect it
+	// doesn't follow ends the fetch, with the redirect's 3xx status.
+	FetchWithin(url string, follow func(url string) bool) *page.Result
+}
+
+// AssetChecker is implemented by Fetchers that can check an asset
+// without fetching and parsing it as a page.
+type AssetChecker interface {
+	// Check finds out whether URL can be fetched; the Result has no
+	// body or links.
+	Check(url string) *page.Result
+}
+
+// SitemapReader is implemented by Fetchers that can read sitemaps.
+type SitemapReader interface {
+	// Sitemap returns every page listed in the sitemaps of
+	// the site url is on.
+	Sitemap(url string) ([]string, error)
+}
 
-// Use this value to mark a URL as busy in the URL cache.
-var errLoading = errors.New("url load in progress")
+// RobotsChecker is implemented by Fetchers that understand robots.txt.
+type RobotsChecker interface {
+	// Allowed reports whether robots.txt lets us fetch URL, and the
+	// Crawl-delay the site wants between requests (zero if none).
+	Allowed(url string) (allowed bool, crawlDelay time.Duration)
+}
+
+const queueSize = 200
 
-// Use this value to mark a URL as leading offsite
-var errOffsite = errors.New("url points offsite")
+// How long an idle worker waits before checking the queue again
+// while other workers are still fetching.
+const idleDelay = 50 * time.Millisecond
 
+// crawlPage takes the next URL off the queue and crawls it. The crawl
+// is complete once the queue is empty and no worker is still fetching
+// a page (and so possibly about to queue more URLs).
 func (state *State) crawlPage() {
+	state.Lock()
 	if state.unprocessed.Empty() {
+		busy := state.inFlight > 0
+		state.Unlock()
+		if busy {
+			// Someone else may still add work; check back shortly.
+			time.Sleep(idleDelay)
+			return
+		}
 		state.Quit()
+		return
 	}
+	// Items are only ever taken off the queue here, under the lock,
+	// so this Get can't block.
 	z, _ := state.unprocessed.Get(1)
 	item := z[0].(unprocessedItem)
-	state.crawl(item.URL, item.insertPoint)
+	id := state.nextActive
+	state.nextActive++
+	state.active[id] = item
+	state.inFlight++
+	state.Unlock()
+
+	state.crawl(item)
+
+	state.Lock()
+	delete(state.active, id)
+	state.inFlight--
+	state.Unlock()
 }
 
 // crawl uses Fetcher to recursively crawl pages starting with URL.
-// Once all links that point to the same domain as the initial URL
-// have been visited, crawling stops.
-func (state *State) crawl(URL string, current *gotree.Tree) {
-	// record URL under current SiteTree. If none exists, create.
+// Once all links that point to the same site as the initial URL
+// (as the crawl's Scope defines it) have been visited, crawling stops.
+func (state *State) crawl(item unprocessedItem) {
+	URL := item.URL
+
+	// record URL in the link graph; the first time we see it, it
+	// goes in the tree under the page it was found on.
 	// If we detect that we're paused inside stoppedOrCrawling(), we
 	// won't exit it until we are moved to stopped or running.
 
@@ -90,41 +227,61 @@ func (state *State) crawl(URL string, current *gotree.Tree) {
 	u, err := url.Parse(URL)
 	if err != nil {
 		state.Lock()
-		state.cache[URL] = err
+		state.cache[URL] = Visit{
+			Outcome: Invalid,
+			Err:     &page.Error{Kind: page.InvalidURL, Message: err.Error()},
+		}
 		state.Unlock()
 		log.Debugf("Invalid URL %s: %s", URL, err.Error())
 	}
 
-	// We want to record the link, even if it's bad.
+	// Once the crawl's out of time, URLs go straight on the frontier;
+	// there's no point asking robots.txt about them.
 	state.Lock()
-	var newT *gotree.Tree
-	if current == nil {
+	expired := state.expired
+	state.Unlock()
+
+	allowed, crawlDelay := true, time.Duration(0)
+	if err == nil {
+		if state.site.contains(u) && !expired {
+			allowed, crawlDelay = state.robots(URL)
+		}
+		// Note how we got here, so we can spot pages that are only
+		// in the sitemap.
+		state.Lock()
+		if item.fromSitemap {
+			state.sitemapped[URL] = true
+		} else {
+			state.linked[URL] = true
+		}
+		state.Unlock()
+	}
+
+	// We want to record the link, even if it's bad.
+	switch {
+	case item.source == "":
 		// Tree's empty; build a new one.
-		newT = state.tree.AddAt(nil, URL)
-		current = newT
-	} else {
-		// Add this URL under the current node.
-		newT = state.tree.AddAt(current, URL)
+		state.tree.AddRoot(URL)
+	case item.fromSitemap:
+		state.tree.AddSitemap(item.source, URL)
+	default:
+		state.tree.AddLink(item.source, URL, item.text)
 	}
-	state.Unlock()
 
 	if err != nil {
 		// Recorded. No further action.
 		return
 	}
 
-	// Relative URLs are still on this domain. Make it so.
-	if string(URL[0]) == "/" {
-		// Make the next test know it lives here.
-		u.Host = state.domain
-		URL = u.String()
-		URL, _ = purify(URL)
+	// Seed the crawl from the sitemap, if asked, once the root is in the tree.
+	if item.source == "" && state.sitemaps && !expired {
+		state.seedFromSitemap(URL)
 	}
 
-	// Are we off our domain?
-	if u.Host != state.domain {
+	// Are we off the site?
+	if !state.site.contains(u) {
 		state.Lock()
-		state.cache[URL] = errOffsite
+		state.cache[URL] = Visit{Outcome: Offsite, Asset: item.asset}
 		state.Unlock()
 		log.Debugf("Offsite URL %s", URL)
 		return
@@ -136,36 +293,362 @@ func (state *State) crawl(URL string, current *gotree.Tree) {
 		log.Debugf("<- Done with %v, already fetched.\n", URL)
 		return
 	}
+	if item.source != "" && !state.matcher.inScope(u) {
+		// We've been told to leave it alone. The root's always crawled.
+		state.cache[URL] = Visit{Outcome: OutOfScope, Asset: item.asset}
+		state.Unlock()
+		log.Debugf("<- Out of scope: %v\n", URL)
+		return
+	}
+	if !allowed {
+		state.cache[URL] = Visit{Outcome: Blocked, Asset: item.asset}
+		state.Unlock()
+		log.Debugf("<- Blocked by robots.txt: %v\n", URL)
+		return
+	}
+	if limit := state.overLimit(item); limit != "" {
+		// Leave it for another crawl. Once a limit on the whole crawl
+		// is hit, everything left in the queue ends up here.
+		state.frontier[URL] = true
+		if state.Limit == "" {
+			state.Limit = limit
+		}
+		state.Unlock()
+		log.Debugf("<- Reached %s, not fetching %v\n", limit, URL)
+		return
+	}
 	// We mark the URL to be loading to avoid others reloading it at the same time.
-	state.cache[URL] = errLoading
+	state.cache[URL] = Visit{Outcome: Fetching, Asset: item.asset}
+	delete(state.frontier, URL)
+	if item.asset == "" {
+		state.fetched++
+	}
 	state.Unlock()
 
+	// Don't hammer the site: wait our turn for this host, allowing
+	// for any Crawl-delay the site asks for.
+	politeness := state.politeness
+	if crawlDelay > politeness.MinDelay {
+		politeness.MinDelay = crawlDelay
+	}
+	state.limiter.Wait(state.limiterID, u.Host, politeness)
+
 	// We load it concurrently.
-	body, urls, err := state.fetcher.Fetch(URL)
+	began := time.Now()
+	result := state.fetch(item)
+	if result.Latency == 0 {
+		result.Latency = time.Since(began)
+	}
+
+	visit := Visit{Outcome: Fetched, Result: result, Asset: item.asset}
+	if result.Err == nil && item.asset == "" {
+		visit.DuplicateOf, visit.DuplicateReason = state.original(URL, result)
+	}
+	switch {
+	case result.Err != nil:
+		log.Debugf("<- Error on %v: %v\n", URL, result.Err)
+		visit.Outcome, visit.Err = Failed, result.Err
+		state.emit(Event{Kind: PageFailed, URL: URL, Status: result.Status, Elapsed: result.Latency, Error: result.Err.Error()})
+	case result.Status >= 300 && result.Status < 400:
+		// A redirect off the site. Record where it goes; that will
+		// be offsite, and not fetched.
+		log.Debugf("<- Not following redirect from %v\n", URL)
+		visit.Outcome = Redirected
+		state.emit(Event{Kind: PageFetched, URL: URL, Status: result.Status, Elapsed: result.Latency})
+		if n := len(result.Redirects); n > 0 {
+			target := result.Redirects[n-1].Location
+			if u, err := state.purify(target); err == nil {
+				target = u
+			}
+			state.enqueue(unprocessedItem{source: URL, URL: target, depth: item.depth + 1, asset: item.asset})
+		}
+	case item.asset != "":
+		// Assets are leaves; even if we had to fetch one as a page,
+		// we don't follow its links.
+		log.Debugf("Checked %s %s\n", item.asset, URL)
+		state.emit(Event{Kind: PageFetched, URL: URL, Status: result.Status, Elapsed: result.Latency})
+	case visit.DuplicateOf != "":
+		// We have this page already, or will; its links are the
+		// same as the original's, so there's no need to follow them.
+		log.Debugf("<- %v is a duplicate of %v (%s)\n", URL, visit.DuplicateOf, visit.DuplicateReason)
+		visit.Outcome = Duplicate
+		state.emit(Event{Kind: PageFetched, URL: URL, Status: result.Status, Elapsed: result.Latency})
+		if visit.DuplicateReason == SameCanonical {
+			// Make sure the canonical page gets crawled.
+			state.enqueue(unprocessedItem{source: URL, URL: visit.DuplicateOf, depth: item.depth + 1})
+		}
+	default:
+		log.Debugf("Found: %s %q\n", URL, result.Body)
+		state.emit(Event{Kind: PageFetched, URL: URL, Status: result.Status, Elapsed: result.Latency})
+		// The Fetcher should have made the links absolute, but any it
+		// didn't are relative to where the fetch ended up.
+		base, _ := url.Parse(result.FinalURL)
+		for i, link := range result.Links {
+			if link.Asset != "" && !state.assets {
+				continue
+			}
+			target, fragment := link.URL, ""
+			if base != nil {
+				if abs, err := base.Parse(target); err == nil {
+					target, fragment = abs.String(), abs.Fragment
+				}
+			}
+			// A URL that won't normalize goes on the queue as it
+			// is, so that it's recorded as invalid.
+			normalize := state.purify
+			if link.Asset != "" {
+				normalize = state.purifyAsset
+			}
+			u, err := normalize(target)
+			if err != nil {
+				u = target
+			}
+			if fragment != "" && link.Asset == "" && err == nil {
+				// Check it once we've fetched the page it's on.
+				state.Lock()
+				state.anchorLinks[AnchorLink{Source: URL, Target: u, Fragment: fragment, Text: link.Text}] = true
+				state.Unlock()
+			}
+			log.Debugf("-> Queuingchild %v/%v of %v : %v.\n", i, len(result.Links), URL, u)
+			child := unprocessedItem{source: URL, URL: u, text: link.Text, depth: item.depth + 1, asset: link.Asset}
+			if link.Asset != "" {
+				child.depth = item.depth
+			}
+			state.enqueue(child)
+		}
+	}
 
-	// And update the status in a synced zone.
+	// And update the status in a synced zone. This comes after the
+	// children are queued, so that a snapshot never sees this page
+	// as done without its children. The body and links aren't kept;
+	// the links are in the graph.
+	kept := *result
+	kept.Body, kept.Links = "", nil
+	visit.Result = &kept
 	state.Lock()
-	state.cache[URL] = err
+	state.cache[URL] = visit
 	state.Unlock()
+	log.Debugf("<- Done with %v\n", URL)
+}
+
+// fetch fetches a page, or checks an asset if the Fetcher knows how.
+func (state *State) fetch(item unprocessedItem) *page.Result {
+	if checker, ok := state.fetcher.(AssetChecker); ok && item.asset != "" {
+		return checker.Check(item.URL)
+	}
+	if scoped, ok := state.fetcher.(ScopedFetcher); ok {
+		return scoped.FetchWithin(item.URL, state.follow)
+	}
+	return state.fetcher.Fetch(item.URL)
+}
 
+// original works out whether a page we've just fetched duplicates
+// another: if it names a different URL as its canonical one, or has the
+// same text as a page we've already fetched. If so, it returns the
+// original and why. Pages are identified by where their fetch ended
+// up, so that a redirect isn't a duplicate of where it goes.
+func (state *State) original(URL string, result *page.Result) (string, DuplicateReason) {
+	final, err := state.purify(result.FinalURL)
 	if err != nil {
-		log.Debugf("<- Error on %v: %v\n", URL, err)
+		final = URL
+	}
+	if result.Canonical != "" {
+		if canonical, err := state.purify(result.Canonical); err == nil && canonical != URL && canonical != final {
+			return canonical, SameCanonical
+		}
+	}
+	if result.Hash == "" {
+		return "", ""
+	}
+	state.Lock()
+	defer state.Unlock()
+	if first, ok := state.hashes[result.Hash]; ok && first != final {
+		return first, SameContent
+	}
+	state.hashes[result.Hash] = final
+	return "", ""
+}
+
+// follow says whether to follow a redirect to URL: only if it's on
+// the site.
+func (state *State) follow(URL string) bool {
+	u, err := url.Parse(URL)
+	return err == nil && state.site.contains(u)
+}
+
+// seedFromSitemap queues every page in the site's sitemaps under
+// the root of the tree.
+func (state *State) seedFromSitemap(URL string) {
+	reader, ok := state.fetcher.(SitemapReader)
+	if !ok {
+		log.Debug("fetcher can't read sitemaps")
 		return
 	}
-	log.Debugf("Found: %s %q\n", URL, body)
-	for i, u := range urls {
-		// Ignoring the error because fetched URLs should already be
-		// valid URLs of some sort.
-		u, _ = purify(u)
-		log.Debugf("-> Queuingchild %v/%v of %v : %v.\n", i, len(urls), URL, u)
-		state.unprocessed.Put(unprocessedItem{URL: u, insertPoint: newT})
+	pages, err := reader.Sitemap(URL)
+	if err != nil {
+		log.Debugf("can't read sitemap for %s: %s", URL, err.Error())
+		return
+	}
+	for _, page := range pages {
+		page, _ = state.purify(page)
+		log.Debugf("-> Queuing sitemap page %v", page)
+		state.enqueue(unprocessedItem{source: URL, URL: page, fromSitemap: true, depth: 1})
 	}
-	log.Debugf("<- Done with %v\n", URL)
 }
 
-func purify(URL string) (string, error) {
-	return purell.NormalizeURLString(URL,
-		purell.FlagsAllNonGreedy&^purell.FlagRemoveDirectoryIndex&^purell.FlagForceHTTP&^purell.FlagAddWWW)
+// enqueue puts an item on the queue, and tells any watchers if its
+// URL is new to the crawl.
+func (state *State) enqueue(item unprocessedItem) {
+	state.Lock()
+	_, visited := state.cache[item.URL]
+	first := !visited && !state.queued[item.URL]
+	state.queued[item.URL] = true
+	expired := state.expired
+	state.Unlock()
+
+	if first {
+		state.emit(Event{Kind: Queued, URL: item.URL})
+	}
+	if expired {
+		// Found by a fetch that finished after time ran out; the
+		// workers are gone, so record it here.
+		state.crawl(item)
+		return
+	}
+	state.unprocessed.Put(item)
+}
+
+// expire ends a crawl whose time has run out. It's run by a timer,
+// so the crawl ends on time even if it's paused or every worker is
+// waiting on a slow fetch. What's left in the queue goes on the
+// frontier, and the workers are stopped; any still fetching finish
+// the page they're on.
+func (state *State) expire() {
+	state.Lock()
+	if state.Done {
+		state.Unlock()
+		return
+	}
+	state.expired = true
+	if state.Limit == "" {
+		state.Limit = fmt.Sprintf("timeout (%v)", state.limits.Timeout)
+	}
+	var items []interface{}
+	if n := state.unprocessed.Len(); n > 0 {
+		items, _ = state.unprocessed.Get(n)
+	}
+	// They're in flight until they're on the frontier, so an idle
+	// worker doesn't end the crawl, and a snapshot doesn't lose them,
+	// before they're all there.
+	first := state.nextActive
+	for _, item := range items {
+		state.active[state.nextActive] = item.(unprocessedItem)
+		state.nextActive++
+	}
+	state.inFlight += len(items)
+	state.Unlock()
+	log.Debugf("Out of time after %v", state.limits.Timeout)
+
+	for i, item := range items {
+		state.crawl(item.(unprocessedItem))
+		state.Lock()
+		delete(state.active, first+i)
+		state.inFlight--
+		state.Unlock()
+	}
+	state.Quit()
+}
+
+// overLimit checks the crawl's limits before we fetch an item, and
+// describes the limit reached, if any. Assets don't count as pages.
+// Must be called holding the lock.
+func (state *State) overLimit(item unprocessedItem) string {
+	limits, depth := state.limits, item.depth
+	switch {
+	case limits.MaxPages > 0 && item.asset == "" && state.fetched >= limits.MaxPages:
+		return fmt.Sprintf("max pages (%d)", limits.MaxPages)
+	case limits.Timeout > 0 && (state.expired || time.Since(state.started) > limits.Timeout):
+		return fmt.Sprintf("timeout (%v)", limits.Timeout)
+	case limits.MaxDepth > 0 && depth > limits.MaxDepth:
+		return fmt.Sprintf("max depth (%d)", limits.MaxDepth)
+	}
+	return ""
+}
+
+// Frontier returns the URLs that were found but not fetched
+// because the crawl reached a limit.
+func (state *State) Frontier() []string {
+	state.Lock()
+	defer state.Unlock()
+	frontier := []string{}
+	for URL := range state.frontier {
+		frontier = append(frontier, URL)
+	}
+	sort.Strings(frontier)
+	return frontier
+}
+
+// Status reports whether the crawl is done, and if so, the limit
+// that ended it early (empty if it ran to completion).
+func (state *State) Status() (done bool, limit string) {
+	state.Lock()
+	defer state.Unlock()
+	return state.Done, state.Limit
+}
+
+// Orphans returns the pages that are listed in the sitemap, but that
+// no page we crawled links to.
+func (state *State) Orphans() []string {
+	state.Lock()
+	defer state.Unlock()
+	orphans := []string{}
+	for page := range state.sitemapped {
+		if !state.linked[page] {
+			orphans = append(orphans, page)
+		}
+	}
+	sort.Strings(orphans)
+	return orphans
+}
+
+// Close ends the crawl for good: the workers are stopped, once they
+// finish the pages they're on, and the tree is shut down. Results
+// can't be read from the crawl after this.
+func (state *State) Close() {
+	if state.Quit != nil {
+		state.Quit()
+		state.Wait()
+	}
+	state.closeWatchers()
+	state.tree.Quit()
+}
+
+// robots checks URL against the site's robots.txt, if the Fetcher
+// knows how and this crawl hasn't been told to ignore it.
+func (state *State) robots(URL string) (bool, time.Duration) {
+	if state.ignoreRobots {
+		return true, 0
+	}
+	checker, ok := state.fetcher.(RobotsChecker)
+	if !ok {
+		return true, 0
+	}
+	return checker.Allowed(URL)
+}
+
+// Normalize normalizes the URL of a page the way this crawl does.
+func (state *State) Normalize(URL string) (string, error) {
+	return state.purify(URL)
+}
+
+// purify normalizes a page's URL by the crawl's policy.
+func (state *State) purify(URL string) (string, error) {
+	return state.normalization.normalize(URL, false)
+}
+
+// purifyAsset normalizes an asset's URL by the crawl's policy.
+func (state *State) purifyAsset(URL string) (string, error) {
+	return state.normalization.normalize(URL, true)
 }
 
 // Debug turns debug logging on or off.
@@ -177,133 +660,237 @@ func Debug(state bool) {
 	}
 }
 
-// Format formats the crawl tree as it stands and returns it.
-func (state *State) Format() string {
-	if state == nil || state.tree == nil {
-		log.Debug("tree is not initialized")
-		return ""
-	}
-	return state.tree.Format()
-}
-
-// New takes a URL and a Fetcher to fetch URLs.
+// New takes a URL, a Fetcher to fetch URLs, and the Options for the crawl.
 // It initializes the crawler's data structures and returns a set of closures
 // that can be used to start, pause, resume, and quit crawling. It also
 // provides a wait() function to ensure that we can wait for the process to
 // complete if we so desire. See https://stackoverflow.com/questions/38798863/golang-pause-a-loop-in-a-goroutine-with-channels
 // holding the crawl state in the State pointer passed in.
-func New(URL string, f Fetcher) *State {
-	state := State{
-		cache:       make(map[string]error),
-		tree:        sharedTree.New(),
-		fetcher:     f,
-		unprocessed: queue.New(queueSize),
-	}
+func New(URL string, f Fetcher, opts Options) *State {
+	matcher, patternErr := opts.Patterns.compile()
+	state := newState(f, opts, matcher)
 	state.tree.Run()
-	b, err := purify(URL)
+	state.root = URL
+	b, err := state.purify(URL)
 	if err != nil {
 		// bad initial URL. fail crawl right away.
 		state.BaseURL = URL
 		state.Done = true
-		return &state
+		return state
 	}
 	state.BaseURL = b
-	// purify() will have returned a valid URL.
+	// state.purify() will have returned a valid URL.
 	u, _ := url.Parse(b)
-	state.domain = u.Host
+	state.site = newSite(state.scope, u)
+	if patternErr != nil {
+		// Crawling without them could go where we were told not
+		// to, so there's nothing to crawl.
+		log.Errorf("not crawling %s: %s", URL, patternErr.Error())
+		state.Done = true
+	} else {
+		state.enqueue(unprocessedItem{URL: b})
+	}
 
-	state.unprocessed.Put(unprocessedItem{URL: URL, insertPoint: nil})
+	state.Start, state.Pause, state.Resume, state.Quit, state.Wait = state.controls()
+	log.Debugf("crawl for %s initialized", URL)
+	return state
+}
 
+// newState sets up an empty crawl with the given options, and the
+// scope patterns already compiled.
+func newState(f Fetcher, opts Options, matcher *matcher) *State {
+	if opts.Workers < 1 {
+		opts.Workers = DefaultWorkers
+	}
+	if opts.Limiter == nil {
+		opts.Limiter = NewHostLimiter()
+	}
 	if os.Getenv("TESTING") != "" {
 		Debug(true)
 	}
-	state.Start, state.Pause, state.Resume, state.Quit, state.Wait = state.controls()
-	log.Debugf("crawl for %s initialized", URL)
-	return &state
+	return &State{
+		cache:         make(map[string]Visit),
+		tree:          sharedTree.New(),
+		fetcher:       f,
+		unprocessed:   queue.New(queueSize),
+		workers:       opts.Workers,
+		active:        make(map[int]unprocessedItem),
+		politeness:    opts.Politeness,
+		limiter:       opts.Limiter,
+		limiterID:     opts.Limiter.Join(),
+		ignoreRobots:  opts.IgnoreRobots,
+		sitemaps:      opts.Sitemaps,
+		assets:        opts.Assets,
+		linked:        make(map[string]bool),
+		sitemapped:    make(map[string]bool),
+		anchorLinks:   make(map[AnchorLink]bool),
+		hashes:        make(map[string]string),
+		limits:        opts.Limits,
+		patterns:      opts.Patterns,
+		scope:         opts.Scope,
+		normalization: opts.Normalization,
+		matcher:       matcher,
+		frontier:      make(map[string]bool),
+		queued:        make(map[string]bool),
+	}
 }
 
 // controls() controls the run/pause behavior for the crawl. It
 // returns the controller functions needed to actually do the
-// control operations on the crawl.
+// control operations on the crawl. The crawl is run by a pool of
+// state.workers goroutines, all pulling URLs from the same queue.
 func (state *State) controls() (start, pause, resume, quit, wait func()) {
 	var (
 		chWork       <-chan struct{}
 		chWorkBackup <-chan struct{}
 		chControl    chan struct{}
+		quitting     bool
+		ctl          sync.Mutex
 		wg           sync.WaitGroup
 	)
 
-	// Routine encapsulates the logic to run one iteration of the
-	// crawl, with run/pause controls.
-	routine := func() {
+	// signal wakes up every worker so that it picks up a change
+	// to chWork, by closing the current control channel and
+	// replacing it for the next change. Must be called holding ctl.
+	signal := func() {
+		if chControl != nil {
+			close(chControl)
+		}
+		chControl = make(chan struct{})
+	}
+
+	// channels gets a consistent view of the control state for
+	// one trip through a worker's select.
+	channels := func() (<-chan struct{}, <-chan struct{}, bool) {
+		ctl.Lock()
+		defer ctl.Unlock()
+		return chWork, chControl, quitting
+	}
+
+	// Routine encapsulates the logic for one worker: crawl a URL
+	// per iteration, with run/pause controls.
+	routine := func(n int) {
 		// Defer this so that if we quit, the waitgroup is closed out.
-		log.Debug("*** RUNLOOP ***")
+		log.Debugf("*** RUNLOOP %d ***", n)
 		defer wg.Done()
 
 		for {
+			work, control, done := channels()
+			if done {
+				return
+			}
 			select {
-			case <-chWork:
-				// crawl another URL, putting its sub-URLs on the queue,
-				// then release the CPU.
-				// If the queue is empty, crawlPage will quit().
+			case <-work:
+				// crawl another URL, putting its sub-URLs on the queue.
+				// If the queue is empty and everyone is idle,
+				// crawlPage will quit().
 				state.crawlPage()
-				time.Sleep(100 * time.Millisecond)
-			case _, ok := <-chControl:
-				if ok {
-					continue
-				}
-				return
+			case <-control:
+				// Paused, resumed, or quit; go around again
+				// to see which.
+				continue
 			}
 		}
 	}
 
 	start = func() {
 		log.Debug("*** START ***")
-		// chWork, chWorkBackup: two closed channels to
+		ctl.Lock()
+		// chWork, chWorkBackup: a closed channel to
 		// force a return when the read is done.
 		ch := make(chan struct{})
 		close(ch)
 		chWork = ch
 		chWorkBackup = ch
+		quitting = false
 
-		// chControl is used to actually control whether we
-		// run more goroutines.
-		chControl = make(chan struct{})
+		// The crawl's time limit runs from when it first starts.
+		state.Lock()
+		if state.started.IsZero() {
+			state.started = time.Now()
+		}
+		if state.limits.Timeout > 0 && state.deadline == nil {
+			state.deadline = time.AfterFunc(state.limits.Timeout-time.Since(state.started), state.expire)
+		}
+		state.Unlock()
+
+		// chControl is used to tell the workers that
+		// chWork has changed.
+		signal()
+		ctl.Unlock()
 
 		// wg
 		wg = sync.WaitGroup{}
-		wg.Add(1)
+		wg.Add(state.workers)
 
-		// Run one more iteration of the crawl. Any URLs
-		// found will be queued to be processed on the next
-		// go-round.
-		go routine()
+		// Start the pool. Any URLs found by a worker will be
+		// queued to be processed by whichever worker is free.
+		for i := 0; i < state.workers; i++ {
+			go routine(i)
+		}
+		state.emit(Event{Kind: Started})
 	}
 
 	pause = func() {
 		log.Debug("*** PAUSE ***")
+		ctl.Lock()
+		defer ctl.Unlock()
+		if quitting {
+			return
+		}
 		// Used to disable the case that actually does work.
 		// (Read from a nil channel in a select case causes
-		// that case to be skipped.)
+		// that case to be skipped.) Workers finish the page
+		// they are on, then wait.
 		chWork = nil
-		chControl <- struct{}{}
+		signal()
+		state.emit(Event{Kind: Paused})
 	}
 
 	resume = func() {
 		log.Debug("*** RESUME ***")
+		ctl.Lock()
+		if chWorkBackup == nil {
+			// Never started (a restored crawl), so start it.
+			ctl.Unlock()
+			start()
+			return
+		}
+		defer ctl.Unlock()
+		if quitting {
+			return
+		}
 		// Restore the channel to re-enable the case.
 		chWork = chWorkBackup
-		chControl <- struct{}{}
+		signal()
+		state.emit(Event{Kind: Resumed})
 	}
 
 	quit = func() {
 		log.Debug("*** QUIT ***")
-		// Read on a nil channel forces a return.
+		ctl.Lock()
+		if quitting {
+			// Another worker got here first.
+			ctl.Unlock()
+			return
+		}
+		quitting = true
 		chWork = nil
-		close(chControl)
+		signal()
+		ctl.Unlock()
 		state.Lock()
 		state.Done = true
+		if state.deadline != nil {
+			state.deadline.Stop()
+			state.deadline = nil
+		}
 		state.Unlock()
+		// Our politeness settings only apply while we're running.
+		state.limiter.Leave(state.limiterID)
+		state.emit(Event{Kind: Finished})
+		// Anyone who missed that because they fell behind still
+		// needs to know the crawl's over.
+		state.closeWatchers()
 	}
 
 	wait = func() {
@@ -1,22 +1,24 @@
 package crawler
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"time"
 
+	fetcher "github.com/joemcmahon/joe_macmahon_technical_test/crawler/fetcher"
+	"github.com/joemcmahon/joe_macmahon_technical_test/crawler/page"
 	"github.com/joemcmahon/joe_macmahon_technical_test/crawler/test/mock_fetcher"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
 
-const fourOhFour = "http://example.com\n"
 const knownURL = "http://golang.org/"
 const unknownURL = "http://example.com"
-const expectedStart = `http://golang.org/
-├── http://golang.org/`
 
 var _ = Describe("crawler", func() {
 	if os.Getenv("TESTING") != "" {
@@ -24,41 +26,753 @@ var _ = Describe("crawler", func() {
 	}
 	Describe("have data", func() {
 		state := runCrawler(knownURL)
-		answer := state.Format()
-		testPrint(answer)
+		answer := state.Results()
+		testPrint(answer, "")
 		Context("scanning data we have", func() {
 			It("scans the fake tree successfully", func() {
-				// ExpectedStart here because the crawl items are
-				// not always going to come back in the same order
-				// past the top level.
-				Expect(strings.HasPrefix(answer, expectedStart)).To(BeTrue())
+				// The crawl items are not always going to come back
+				// in the same order, so just check the shape.
+				Expect(answer.URL).To(Equal(knownURL))
+				Expect(answer.Outcome).To(Equal(Fetched))
+				Expect(answer.Children).ToNot(BeEmpty())
+				for _, child := range answer.Children {
+					Expect(strings.HasPrefix(child.URL, knownURL)).To(BeTrue())
+					Expect(child.Parent).To(Equal(knownURL))
+					Expect(child.Depth).To(Equal(1))
+				}
 				Expect(state.Done).To(BeTrue())
 			})
+			It("summarizes the crawl", func() {
+				stats := state.Stats()
+				Expect(stats.Started).ToNot(BeZero())
+				Expect(stats.Fetched).To(Equal(5))
+				Expect(stats.Queued).To(BeZero())
+				Expect(stats.Errors).To(Equal(1))
+			})
+			It("records what happened to each page", func() {
+				Expect(find(answer, "http://golang.org/pkg/fmt/").Depth).To(Equal(2))
+				Expect(find(answer, "http://golang.org/cmd/").Outcome).To(Equal(Failed))
+				Expect(find(answer, "http://golang.org/cmd/").Error).To(Equal("not found: http://golang.org/cmd/"))
+			})
+			It("records the fetch results", func() {
+				pkg := find(answer, "http://golang.org/pkg/fmt/")
+				Expect(pkg.Status).To(Equal(200))
+				Expect(pkg.FinalURL).To(Equal("http://golang.org/pkg/fmt/"))
+				Expect(pkg.ContentType).To(HavePrefix("text/html"))
+				Expect(pkg.Size).To(Equal(len("Package fmt")))
+				Expect(pkg.Latency).ToNot(BeZero())
+				cmd := find(answer, "http://golang.org/cmd/")
+				Expect(cmd.Status).To(Equal(404))
+				Expect(cmd.ErrorKind).To(Equal(page.HTTPError))
+			})
+			It("lists the broken links", func() {
+				broken := state.Broken()
+				Expect(broken).To(HaveLen(1))
+				Expect(broken[0].URL).To(Equal("http://golang.org/cmd/"))
+				Expect(broken[0].Status).To(Equal(404))
+				Expect(broken[0].LinkedFrom).To(ConsistOf(
+					page.Link{URL: "http://golang.org/", Text: "Commands"},
+					page.Link{URL: "http://golang.org/pkg/", Text: "Commands"},
+				))
+			})
 		})
 	})
 	Describe("don't have data", func() {
 		state := runCrawler(unknownURL)
-		answer := state.Format()
-		testPrint(answer)
+		answer := state.Results()
+		testPrint(answer, "")
 		Context("scanning data we don't have", func() {
 			It("shows the empty tree as we expect it", func() {
-				Expect(answer).To(Equal("http://example.com\n"))
+				// The root is normalized like any other URL.
+				Expect(answer.URL).To(Equal(unknownURL + "/"))
+				Expect(answer.Outcome).To(Equal(Failed))
+				Expect(answer.Children).To(BeEmpty())
 				Expect(state.Done).To(BeTrue())
 			})
 		})
 	})
+	Describe("link graph", func() {
+		state := New(knownURL, MockFetcher.New(), Options{})
+		state.Start()
+		state.Wait()
+		answer := state.Results()
+		It("puts each URL in the tree once", func() {
+			seen := map[string]int{}
+			var count func(*Result)
+			count = func(r *Result) {
+				seen[r.URL]++
+				for _, child := range r.Children {
+					count(child)
+				}
+			}
+			count(answer)
+			for URL, n := range seen {
+				Expect(n).To(Equal(1), URL)
+			}
+			Expect(seen).To(HaveLen(6))
+		})
+		It("records every link to a page", func() {
+			Expect(find(answer, "http://golang.org/pkg/").LinkedFrom).To(ConsistOf(
+				page.Link{URL: "http://golang.org/", Text: "Packages"},
+				page.Link{URL: "http://golang.org/pkg/fmt/", Text: "Packages"},
+				page.Link{URL: "http://golang.org/pkg/os/", Text: "Packages"},
+			))
+			Expect(find(answer, "http://golang.org/cmd/").LinkedFrom).To(HaveLen(2))
+		})
+	})
+	Describe("snapshots", func() {
+		// Round trip through JSON, as the server does.
+		roundTrip := func(snap Snapshot) Snapshot {
+			data, err := json.Marshal(snap)
+			Expect(err).ToNot(HaveOccurred())
+			var restored Snapshot
+			Expect(json.Unmarshal(data, &restored)).To(Succeed())
+			return restored
+		}
+		Context("of a finished crawl", func() {
+			state := New(knownURL, MockFetcher.New(), Options{Sitemaps: true})
+			state.Start()
+			state.Wait()
+			It("has the same results", func() {
+				restored, err := Restore(roundTrip(state.Snapshot()), MockFetcher.New())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(restored.Results()).To(Equal(state.Results()))
+				Expect(restored.Orphans()).To(Equal(state.Orphans()))
+				Expect(restored.Done).To(BeTrue())
+			})
+		})
+		Context("of a crawl interrupted part way", func() {
+			It("finishes the crawl", func() {
+				state := New(knownURL, MockFetcher.New(), Options{Workers: 2})
+				state.Start()
+				state.Pause()
+				snap := roundTrip(state.Snapshot())
+				state.Quit()
+				state.Wait()
+				restored, err := Restore(snap, MockFetcher.New())
+				Expect(err).NotTo(HaveOccurred())
+				// Resuming a restored crawl starts it.
+				restored.Resume()
+				restored.Wait()
+				Expect(restored.Done).To(BeTrue())
+				for _, page := range []string{
+					"http://golang.org/",
+					"http://golang.org/pkg/",
+					"http://golang.org/pkg/fmt/",
+					"http://golang.org/pkg/os/",
+				} {
+					Expect(restored.cache).To(HaveKey(page))
+					Expect(restored.cache[page].Outcome).To(Equal(Fetched))
+				}
+				Expect(restored.cache["http://golang.org/private/"].Outcome).To(Equal(Blocked))
+				Expect(restored.Results().Children).ToNot(BeEmpty())
+			})
+		})
+	})
+	Describe("snapshots taken mid-fetch", func() {
+		It("don't count the page being fetched twice", func() {
+			f := &stalledFetcher{Fetcher: MockFetcher.New(), stall: knownURL, release: make(chan bool)}
+			opts := Options{Limits: Limits{MaxPages: 1}}
+			state := New(knownURL, f, opts)
+			state.Start()
+			Eventually(func() Outcome {
+				state.Lock()
+				defer state.Unlock()
+				return state.cache[knownURL].Outcome
+			}).Should(Equal(Fetching))
+			snap := state.Snapshot()
+			close(f.release)
+			state.Wait()
+			Expect(snap.Fetched).To(BeZero())
+
+			restored, err := Restore(snap, MockFetcher.New())
+			Expect(err).NotTo(HaveOccurred())
+			restored.Resume()
+			restored.Wait()
+			Expect(restored.cache[knownURL].Outcome).To(Equal(Fetched))
+			Expect(restored.Stats().Fetched).To(Equal(1))
+		})
+	})
+	Describe("events", func() {
+		It("tells watchers what's happening", func() {
+			state := New(knownURL, MockFetcher.New(), Options{})
+			events, cancel := state.Watch()
+			defer cancel()
+			state.Start()
+			state.Wait()
+
+			kinds := map[EventKind][]string{}
+			statuses := map[string]int{}
+			var first, last Event
+			for len(events) > 0 {
+				e := <-events
+				if first.Time.IsZero() {
+					first = e
+				}
+				last = e
+				kinds[e.Kind] = append(kinds[e.Kind], e.URL)
+				if e.Kind == PageFetched || e.Kind == PageFailed {
+					statuses[e.URL] = e.Status
+				}
+			}
+			Expect(first.Kind).To(Equal(Started))
+			Expect(last.Kind).To(Equal(Finished))
+			Expect(kinds[PageFetched]).To(ConsistOf(
+				"http://golang.org/",
+				"http://golang.org/pkg/",
+				"http://golang.org/pkg/fmt/",
+				"http://golang.org/pkg/os/",
+			))
+			Expect(kinds[PageFailed]).To(Equal([]string{"http://golang.org/cmd/"}))
+			Expect(statuses["http://golang.org/"]).To(Equal(200))
+			Expect(statuses["http://golang.org/cmd/"]).To(Equal(404))
+			// Each new URL is queued once; the root was queued before we watched.
+			Expect(kinds[Queued]).To(ConsistOf(
+				"http://golang.org/pkg/",
+				"http://golang.org/cmd/",
+				"http://golang.org/private/",
+				"http://golang.org/pkg/fmt/",
+				"http://golang.org/pkg/os/",
+			))
+		})
+		It("stops sending when the watcher cancels", func() {
+			state := New(knownURL, MockFetcher.New(), Options{})
+			events, cancel := state.Watch()
+			cancel()
+			state.Start()
+			state.Wait()
+			_, open := <-events
+			Expect(open).To(BeFalse())
+		})
+		It("closes the watcher's channel when the crawl ends, even if it's behind", func() {
+			state := New(knownURL, MockFetcher.New(), Options{})
+			events, cancel := state.Watch()
+			defer cancel()
+			for i := 0; i < watchBuffer; i++ {
+				state.emit(Event{Kind: Queued})
+			}
+			state.Start()
+			state.Wait()
+
+			// Finished was dropped, but the channel still ends.
+			seen := 0
+			for e := range events {
+				Expect(e.Kind).To(Equal(Queued))
+				seen++
+			}
+			Expect(seen).To(Equal(watchBuffer))
+		})
+		It("closes the watcher's channel when the crawl's closed", func() {
+			state := New(knownURL, MockFetcher.New(), Options{})
+			events, cancel := state.Watch()
+			defer cancel()
+			state.Close()
+			Eventually(events).Should(BeClosed())
+
+			// And anyone who starts watching afterwards.
+			late, _ := state.Watch()
+			Expect(late).To(BeClosed())
+		})
+	})
+	Describe("robots.txt", func() {
+		Context("honoring it", func() {
+			state := New(knownURL, MockFetcher.New(), Options{})
+			state.Start()
+			state.Wait()
+			It("doesn't fetch disallowed pages", func() {
+				Expect(state.cache["http://golang.org/private/"].Outcome).To(Equal(Blocked))
+				Expect(find(state.Results(), "http://golang.org/private/").Outcome).To(Equal(Blocked))
+			})
+		})
+		Context("overridden", func() {
+			state := New(knownURL, MockFetcher.New(), Options{IgnoreRobots: true})
+			state.Start()
+			state.Wait()
+			It("fetches disallowed pages anyway", func() {
+				// The mock has no such page, so the fetch fails.
+				Expect(state.cache["http://golang.org/private/"].Err).To(MatchError("not found: http://golang.org/private/"))
+				Expect(find(state.Results(), "http://golang.org/private/").Outcome).To(Equal(Failed))
+			})
+		})
+	})
+	Describe("scope patterns", func() {
+		state := New(knownURL, MockFetcher.New(), Options{
+			Patterns: Patterns{Exclude: []string{"/cmd/*", "re:^/pkg/.+"}},
+		})
+		state.Start()
+		state.Wait()
+		answer := state.Results()
+		It("records excluded URLs as out of scope", func() {
+			Expect(find(answer, "http://golang.org/cmd/").Outcome).To(Equal(OutOfScope))
+			Expect(find(answer, "http://golang.org/pkg/fmt/").Outcome).To(Equal(OutOfScope))
+			Expect(find(answer, "http://golang.org/pkg/").Outcome).To(Equal(Fetched))
+			Expect(state.Stats().Fetched).To(Equal(2))
+		})
+		It("always crawls the root", func() {
+			state := New(knownURL, MockFetcher.New(), Options{
+				Patterns: Patterns{Include: []string{"/pkg/*"}},
+			})
+			state.Start()
+			state.Wait()
+			Expect(find(state.Results(), knownURL).Outcome).To(Equal(Fetched))
+			Expect(find(state.Results(), "http://golang.org/pkg/").Outcome).To(Equal(Fetched))
+			Expect(find(state.Results(), "http://golang.org/cmd/").Outcome).To(Equal(OutOfScope))
+		})
+		It("keeps the patterns in snapshots", func() {
+			Expect(state.Snapshot().Options.Patterns).To(Equal(Patterns{Exclude: []string{"/cmd/*", "re:^/pkg/.+"}}))
+		})
+		It("won't crawl with a bad pattern", func() {
+			state := New(knownURL, MockFetcher.New(), Options{
+				Patterns: Patterns{Exclude: []string{"re:("}},
+			})
+			Expect(state.Done).To(BeTrue())
+			Expect(state.unprocessed.Empty()).To(BeTrue())
+		})
+		It("won't restore a crawl with a bad pattern", func() {
+			snap := state.Snapshot()
+			snap.Options.Patterns.Exclude = []string{"re:("}
+			_, err := Restore(snap, MockFetcher.New())
+			Expect(err).To(MatchError(ContainSubstring(`bad pattern "re:("`)))
+		})
+	})
+	Describe("assets", func() {
+		const (
+			style  = "http://golang.org/lib/godoc/style.css"
+			logo   = "http://golang.org/lib/godoc/images/go-logo-blue.svg"
+			gopher = "http://golang.org/lib/godoc/images/gopher.png"
+		)
+		Context("not asked for", func() {
+			It("only follows links to pages", func() {
+				state := New(knownURL, MockFetcher.New(), Options{})
+				state.Start()
+				state.Wait()
+				Expect(state.cache).ToNot(HaveKey(style))
+				Expect(find(state.Results(), logo)).To(BeNil())
+			})
+		})
+		Context("asked for", func() {
+			state := New(knownURL, MockFetcher.New(), Options{Assets: true})
+			state.Start()
+			state.Wait()
+			answer := state.Results()
+			It("checks them as leaves of their own kind", func() {
+				css := find(answer, style)
+				Expect(css.Outcome).To(Equal(Fetched))
+				Expect(css.Asset).To(Equal(page.Stylesheet))
+				Expect(css.ContentType).To(Equal("text/css"))
+				Expect(css.Children).To(BeEmpty())
+				Expect(css.LinkedFrom).To(HaveLen(2))
+				Expect(find(answer, logo).Asset).To(Equal(page.Image))
+				Expect(find(answer, "http://golang.org/pkg/").Asset).To(BeEmpty())
+			})
+			It("reports missing ones as broken", func() {
+				Expect(find(answer, gopher).Outcome).To(Equal(Failed))
+				Expect(find(answer, gopher).Status).To(Equal(404))
+				broken := []string{}
+				for _, link := range state.Broken() {
+					broken = append(broken, link.URL)
+				}
+				Expect(broken).To(ConsistOf("http://golang.org/cmd/", gopher))
+			})
+			It("doesn't count them as pages", func() {
+				Expect(state.Stats().Fetched).To(Equal(5))
+			})
+			It("keeps the asset mode in snapshots", func() {
+				Expect(state.Snapshot().Options.Assets).To(BeTrue())
+			})
+		})
+	})
+	Describe("anchors", func() {
+		state := New(knownURL, MockFetcher.New(), Options{})
+		state.Start()
+		state.Wait()
+		It("records the anchors on each page", func() {
+			Expect(find(state.Results(), "http://golang.org/pkg/").Anchors).To(Equal([]string{"fmt", "os"}))
+		})
+		It("reports fragments that aren't on the page", func() {
+			Expect(state.BrokenAnchors()).To(Equal([]BrokenAnchor{{
+				URL:        "http://golang.org/pkg/",
+				Fragment:   "syscall",
+				LinkedFrom: []page.Link{{URL: "http://golang.org/pkg/os/", Text: "Packages"}},
+			}}))
+		})
+		It("keeps the links to check in snapshots", func() {
+			restored, err := Restore(state.Snapshot(), MockFetcher.New())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored.BrokenAnchors()).To(Equal(state.BrokenAnchors()))
+		})
+	})
+	Describe("relative links", func() {
+		It("fetches the pages they resolve to from a real server", func() {
+			requested := make(chan string, 10)
+			mux := http.NewServeMux()
+			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				requested <- r.URL.Path
+				switch r.URL.Path {
+				case "/":
+					fmt.Fprint(w, `<html><body><a href="docs/page2.html">Page 2</a></body></html>`)
+				case "/docs/page2.html":
+					fmt.Fprint(w, `<html><body><a href="page3.html">Page 3</a></body></html>`)
+				case "/docs/page3.html":
+					fmt.Fprint(w, `<html><body>The end</body></html>`)
+				default:
+					http.NotFound(w, r)
+				}
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			state := New(server.URL+"/", fetcher.New(""), Options{})
+			state.Start()
+			state.Wait()
+			answer := state.Results()
+			for _, path := range []string{"/docs/page2.html", "/docs/page3.html"} {
+				Expect(find(answer, server.URL+path)).ToNot(BeNil(), path)
+				Expect(find(answer, server.URL+path).Outcome).To(Equal(Fetched), path)
+			}
+			Expect(state.Broken()).To(BeEmpty())
+			close(requested)
+			paths := []string{}
+			for path := range requested {
+				paths = append(paths, path)
+			}
+			Expect(paths).ToNot(ContainElement("/docs/page2.html/"))
+		})
+	})
+	Describe("redirects", func() {
+		const blog = "http://golang.org/blog/"
+		state := New(blog, MockFetcher.New(), Options{})
+		state.Start()
+		state.Wait()
+		answer := state.Results()
+		It("records each hop", func() {
+			moved := find(answer, blog+"moved/")
+			Expect(moved.Outcome).To(Equal(Fetched))
+			Expect(moved.FinalURL).To(Equal(blog + "latest/"))
+			Expect(moved.Redirects).To(HaveLen(2))
+			Expect(find(answer, blog+"renamed/").Redirects).To(Equal([]page.Redirect{
+				{URL: blog + "renamed/", Status: 301, Location: blog + "latest/"},
+			}))
+		})
+		It("doesn't follow redirects off the site", func() {
+			away := find(answer, blog+"away/")
+			Expect(away.Outcome).To(Equal(Redirected))
+			Expect(away.Status).To(Equal(301))
+			Expect(away.Children).To(HaveLen(1))
+			Expect(away.Children[0].URL).To(Equal("http://blog.golang.org/"))
+			Expect(away.Children[0].Outcome).To(Equal(Offsite))
+		})
+		It("fails redirect loops", func() {
+			loop := find(answer, blog+"loop/")
+			Expect(loop.Outcome).To(Equal(Failed))
+			Expect(loop.ErrorKind).To(Equal(page.RedirectLoop))
+		})
+		It("reports chains longer than one hop, and loops", func() {
+			chains := state.RedirectChains()
+			Expect(chains).To(HaveLen(2))
+			Expect(chains[0].URL).To(Equal(blog + "loop/"))
+			Expect(chains[0].ErrorKind).To(Equal(page.RedirectLoop))
+			Expect(chains[1].URL).To(Equal(blog + "moved/"))
+			Expect(chains[1].FinalURL).To(Equal(blog + "latest/"))
+			Expect(chains[1].LinkedFrom).To(Equal([]page.Link{{URL: blog, Text: "Moved"}}))
+		})
+	})
+	Describe("duplicates", func() {
+		const news = "http://golang.org/news/"
+		state := New(news, MockFetcher.New(), Options{})
+		state.Start()
+		state.Wait()
+		answer := state.Results()
+		It("collapses pages with the same canonical URL", func() {
+			byID := find(answer, news+"item/?id=1")
+			Expect(byID.Outcome).To(Equal(Duplicate))
+			Expect(byID.DuplicateOf).To(Equal(news + "item/1/"))
+			Expect(byID.DuplicateReason).To(Equal(SameCanonical))
+			Expect(find(answer, news+"item/1/").Outcome).To(Equal(Fetched))
+		})
+		It("collapses pages with the same text", func() {
+			page1 := find(answer, news+"?page=1")
+			Expect(page1.Outcome).To(Equal(Duplicate))
+			Expect(page1.DuplicateOf).To(Equal(news))
+			Expect(page1.DuplicateReason).To(Equal(SameContent))
+			// Its links aren't followed.
+			Expect(page1.Children).To(BeEmpty())
+			Expect(state.cache).ToNot(HaveKey(news + "?page=2"))
+		})
+		It("groups the duplicates", func() {
+			Expect(state.Duplicates()).To(Equal([]DuplicateGroup{
+				{URL: news, Duplicates: []DuplicatePage{{URL: news + "?page=1", Reason: SameContent}}},
+				{URL: news + "item/1/", Duplicates: []DuplicatePage{
+					{URL: news + "item/?id=1", Reason: SameCanonical},
+					{URL: news + "item/?id=1&ref=home", Reason: SameCanonical},
+				}},
+			}))
+		})
+		It("still spots duplicates after a restore", func() {
+			restored, err := Restore(state.Snapshot(), MockFetcher.New())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored.hashes).To(Equal(state.hashes))
+		})
+	})
+	Describe("audit", func() {
+		state := New(knownURL, MockFetcher.New(), Options{Sitemaps: true})
+		state.Start()
+		state.Wait()
+		audit := state.Audit()
+		problems := map[string][]Problem{}
+		for _, a := range audit {
+			problems[a.URL] = a.Problems
+		}
+		It("keeps what each page says about itself", func() {
+			root := state.Results()
+			Expect(root.Meta).ToNot(BeNil())
+			Expect(root.Meta.Title).To(Equal("The Go Programming Language"))
+			Expect(root.Meta.OutboundLinks).To(Equal(3))
+		})
+		It("leaves out pages with nothing wrong with them", func() {
+			Expect(problems).ToNot(HaveKey(knownURL))
+		})
+		It("flags the problems with the rest", func() {
+			Expect(problems).To(Equal(map[string][]Problem{
+				"http://golang.org/pkg/":     {DuplicateTitle, MissingDescription, MultipleH1s, ThinPage},
+				"http://golang.org/doc/":     {DuplicateTitle, ThinPage},
+				"http://golang.org/pkg/fmt/": {MissingTitle, ThinPage},
+				"http://golang.org/pkg/os/":  {ThinPage},
+			}))
+		})
+		It("says which pages have the same title", func() {
+			for _, a := range audit {
+				if a.URL == "http://golang.org/doc/" {
+					Expect(a.SameTitle).To(Equal([]string{"http://golang.org/pkg/"}))
+				}
+			}
+		})
+	})
+	Describe("perf", func() {
+		state := New(knownURL, MockFetcher.New(), Options{})
+		state.Start()
+		state.Wait()
+		perf := state.Perf(2)
+		It("summarizes the pages' timings and sizes", func() {
+			// The home page, packages, fmt and os, plus cmd's 404.
+			Expect(perf.Pages).To(Equal(5))
+			Expect(perf.Fetch).To(Equal(Timing{P50: 200 * time.Millisecond, P95: 900 * time.Millisecond, Max: 900 * time.Millisecond}))
+			Expect(perf.FirstByte.P50).To(Equal(100 * time.Millisecond))
+			Expect(perf.FirstByte.Max).To(Equal(600 * time.Millisecond))
+			Expect(perf.TotalBytes).To(Equal(int64(40000 + 250000 + len("Package fmt") + 60000)))
+		})
+		It("lists the slowest and heaviest pages", func() {
+			Expect(perf.Slowest).To(HaveLen(2))
+			Expect(perf.Slowest[0].URL).To(Equal("http://golang.org/pkg/"))
+			Expect(perf.Slowest[1].URL).To(Equal("http://golang.org/pkg/os/"))
+			Expect(perf.Heaviest[0]).To(Equal(PagePerf{
+				URL:       "http://golang.org/pkg/",
+				Status:    200,
+				FirstByte: 600 * time.Millisecond,
+				Fetch:     900 * time.Millisecond,
+				Size:      250000,
+			}))
+			Expect(perf.Heaviest[1].URL).To(Equal("http://golang.org/pkg/os/"))
+		})
+		It("lists the default number of pages if not told", func() {
+			Expect(state.Perf(0).Slowest).To(HaveLen(5))
+		})
+	})
+	Describe("normalization", func() {
+		const news = "http://golang.org/news/"
+		policy := Normalization{StripParams: []string{"utm_*", "ref"}}
+		state := New(news+"?utm_source=mail", MockFetcher.New(), Options{Normalization: policy})
+		state.Start()
+		state.Wait()
+		answer := state.Results()
+		It("normalizes the root URL", func() {
+			Expect(state.BaseURL).To(Equal(news))
+			Expect(answer.URL).To(Equal(news))
+		})
+		It("normalizes the links it finds", func() {
+			Expect(state.cache).To(HaveKey(news + "item/?id=1"))
+			Expect(state.cache).ToNot(HaveKey(news + "item/?id=1&ref=home"))
+		})
+		It("keeps the policy in a snapshot", func() {
+			Expect(state.Snapshot().Options.Normalization).To(Equal(policy))
+		})
+	})
+	Describe("sitemaps", func() {
+		Context("not asked for", func() {
+			state := New(knownURL, MockFetcher.New(), Options{})
+			state.Start()
+			state.Wait()
+			It("only follows links", func() {
+				Expect(state.cache).ToNot(HaveKey("http://golang.org/doc/"))
+				Expect(state.Orphans()).To(BeEmpty())
+			})
+		})
+		Context("asked for", func() {
+			state := New(knownURL, MockFetcher.New(), Options{Sitemaps: true})
+			state.Start()
+			state.Wait()
+			It("crawls the sitemap's pages too", func() {
+				Expect(state.cache).To(HaveKey("http://golang.org/doc/"))
+				Expect(state.cache["http://golang.org/doc/"].Outcome).To(Equal(Fetched))
+				doc := find(state.Results(), "http://golang.org/doc/")
+				Expect(doc.Sitemap).To(BeTrue())
+				Expect(doc.Outcome).To(Equal(Fetched))
+			})
+			It("reports pages nothing links to as orphans", func() {
+				Expect(state.Orphans()).To(Equal([]string{"http://golang.org/doc/"}))
+				Expect(find(state.Results(), "http://golang.org/doc/").Orphaned).To(BeTrue())
+			})
+		})
+	})
+	Describe("limits", func() {
+		Context("on pages", func() {
+			state := New(knownURL, MockFetcher.New(), Options{Limits: Limits{MaxPages: 2}})
+			state.Start()
+			state.Wait()
+			It("stops after fetching that many", func() {
+				done, limit := state.Status()
+				Expect(done).To(BeTrue())
+				Expect(limit).To(Equal("max pages (2)"))
+				Expect(state.fetched).To(Equal(2))
+				Expect(state.Frontier()).ToNot(BeEmpty())
+				Expect(find(state.Results(), state.Frontier()[0]).Outcome).To(Equal(Unfetched))
+			})
+		})
+		Context("on depth", func() {
+			state := New(knownURL, MockFetcher.New(), Options{Limits: Limits{MaxDepth: 1}})
+			state.Start()
+			state.Wait()
+			It("doesn't go further from the root", func() {
+				_, limit := state.Status()
+				Expect(limit).To(Equal("max depth (1)"))
+				Expect(state.cache["http://golang.org/pkg/"].Outcome).To(Equal(Fetched))
+				Expect(state.Frontier()).To(Equal([]string{
+					"http://golang.org/pkg/fmt/",
+					"http://golang.org/pkg/os/",
+				}))
+			})
+		})
+		Context("on time", func() {
+			state := New(knownURL, MockFetcher.New(), Options{Limits: Limits{Timeout: time.Nanosecond}})
+			state.Start()
+			state.Wait()
+			It("stops when time runs out", func() {
+				_, limit := state.Status()
+				Expect(limit).To(Equal("timeout (1ns)"))
+				Expect(state.Frontier()).To(Equal([]string{knownURL}))
+			})
+		})
+		Context("on time, while paused", func() {
+			state := New(knownURL, MockFetcher.New(), Options{Limits: Limits{Timeout: 200 * time.Millisecond}})
+			state.Start()
+			state.Pause()
+			It("stops when time runs out, without being resumed", func() {
+				Eventually(func() bool {
+					done, _ := state.Status()
+					return done
+				}, time.Second).Should(BeTrue())
+				state.Wait()
+				_, limit := state.Status()
+				Expect(limit).To(Equal("timeout (200ms)"))
+				Expect(state.Frontier()).ToNot(BeEmpty())
+				for _, URL := range state.Frontier() {
+					Expect(find(state.Results(), URL)).ToNot(BeNil(), URL)
+				}
+			})
+		})
+		Context("not reached", func() {
+			state := New(knownURL, MockFetcher.New(), Options{Limits: Limits{MaxPages: 100}})
+			state.Start()
+			state.Wait()
+			It("finishes normally", func() {
+				done, limit := state.Status()
+				Expect(done).To(BeTrue())
+				Expect(limit).To(BeEmpty())
+				Expect(state.Frontier()).To(BeEmpty())
+			})
+		})
+	})
+	Describe("worker pool", func() {
+		Context("with several workers", func() {
+			state := New(knownURL, MockFetcher.New(), Options{Workers: 8})
+			state.Start()
+			state.Wait()
+			It("fetches every page exactly once", func() {
+				Expect(state.Done).To(BeTrue())
+				for _, page := range []string{
+					"http://golang.org/",
+					"http://golang.org/pkg/",
+					"http://golang.org/cmd/",
+					"http://golang.org/pkg/fmt/",
+					"http://golang.org/pkg/os/",
+				} {
+					Expect(state.cache).To(HaveKey(page))
+				}
+				Expect(state.cache["http://golang.org/pkg/"].Outcome).To(Equal(Fetched))
+				Expect(state.inFlight).To(Equal(0))
+			})
+		})
+		Context("with no worker count", func() {
+			state := New(knownURL, MockFetcher.New(), Options{})
+			It("uses the default", func() {
+				Expect(state.workers).To(Equal(DefaultWorkers))
+			})
+		})
+		Context("paused and resumed", func() {
+			state := New(knownURL, MockFetcher.New(), Options{Workers: 4})
+			state.Start()
+			state.Pause()
+			state.Resume()
+			state.Wait()
+			It("still completes the crawl", func() {
+				Expect(state.Done).To(BeTrue())
+				Expect(state.cache).To(HaveKey("http://golang.org/pkg/os/"))
+			})
+		})
+	})
 })
 
-func testPrint(s string) {
-	if os.Getenv("TESTING") != "" {
-		fmt.Println(s)
+func testPrint(r *Result, indent string) {
+	if r == nil || os.Getenv("TESTING") == "" {
+		return
+	}
+	fmt.Printf("%s%s (%s)\n", indent, r.URL, r.Outcome)
+	for _, child := range r.Children {
+		testPrint(child, indent+"  ")
+	}
+}
+
+// stalledFetcher holds up the fetch of one URL until it's released.
+type stalledFetcher struct {
+	Fetcher
+	stall   string
+	release chan bool
+}
+
+func (f *stalledFetcher) Fetch(URL string) *page.Result {
+	if URL == f.stall {
+		<-f.release
+	}
+	return f.Fetcher.Fetch(URL)
+}
+
+// find returns the first node in the tree for URL, or nil.
+func find(r *Result, URL string) *Result {
+	if r == nil || r.URL == URL {
+		return r
+	}
+	for _, child := range r.Children {
+		if found := find(child, URL); found != nil {
+			return found
+		}
 	}
+	return nil
 }
 
-func runCrawler(url string) State {
+func runCrawler(url string) *State {
 	f := MockFetcher.New()
-	state := New(url, f)
+	state := New(url, f, Options{})
 	state.Start()
 	time.Sleep(5 * time.Second)
-	return *state
+	return state
 }
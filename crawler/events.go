@@ -0,0 +1,112 @@
+package crawler
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// EventKind says what an Event is about.
+type EventKind int
+
+const (
+	// Queued: a URL we haven't seen before was queued to be crawled.
+	Queued EventKind = iota
+	// PageFetched: a page was fetched.
+	PageFetched
+	// PageFailed: a page couldn't be fetched.
+	PageFailed
+	// Started: the crawl started running.
+	Started
+	// Paused: the crawl was paused.
+	Paused
+	// Resumed: the crawl was resumed after a pause.
+	Resumed
+	// Finished: the crawl is over. No more events follow.
+	Finished
+)
+
+// Event is something that happened during a crawl.
+type Event struct {
+	Kind EventKind
+	Time time.Time
+	URL  string
+	// Status is the HTTP status of a fetch; zero if unknown.
+	Status int
+	// Elapsed is how long a fetch took.
+	Elapsed time.Duration
+	// Error is why a fetch failed.
+	Error string
+}
+
+// How many events a watcher can fall behind by before it misses some.
+const watchBuffer = 256
+
+// watchers holds the channels of everyone watching a crawl.
+type watchers struct {
+	sync.Mutex
+	next   int
+	chans  map[int]chan Event
+	closed bool // the crawl is over; there'll be no more events
+}
+
+// Watch returns a channel that gets the crawl's events as they happen,
+// and a function to call to stop watching. A watcher that falls too
+// far behind misses events rather than holding up the crawl. The
+// channel is closed when the crawl ends, even if the watcher missed
+// the Finished event.
+func (state *State) Watch() (<-chan Event, func()) {
+	w := &state.watchers
+	w.Lock()
+	defer w.Unlock()
+	ch := make(chan Event, watchBuffer)
+	if w.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	if w.chans == nil {
+		w.chans = make(map[int]chan Event)
+	}
+	id := w.next
+	w.next++
+	w.chans[id] = ch
+
+	cancel := func() {
+		w.Lock()
+		defer w.Unlock()
+		// The channel may already have been closed by the crawl ending.
+		if _, ok := w.chans[id]; ok {
+			delete(w.chans, id)
+			close(ch)
+		}
+	}
+	return ch, cancel
+}
+
+// emit sends an event to everyone watching the crawl.
+func (state *State) emit(e Event) {
+	e.Time = time.Now()
+	w := &state.watchers
+	w.Lock()
+	defer w.Unlock()
+	for id, ch := range w.chans {
+		select {
+		case ch <- e:
+		default:
+			log.Debugf("watcher %d is behind, dropped event for %s", id, e.URL)
+		}
+	}
+}
+
+// closeWatchers closes every watcher's channel, once the crawl's over.
+func (state *State) closeWatchers() {
+	w := &state.watchers
+	w.Lock()
+	defer w.Unlock()
+	for id, ch := range w.chans {
+		delete(w.chans, id)
+		close(ch)
+	}
+	w.closed = true
+}
@@ -1,54 +1,632 @@
 package Fetcher
 
 import (
+	"compress/gzip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
 	"net/url"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/gocolly/colly"
+	"github.com/joemcmahon/joe_macmahon_technical_test/crawler/page"
 	log "github.com/sirupsen/logrus"
+	"github.com/temoto/robotstxt"
 )
 
+// DefaultUserAgent is the user agent used if none is supplied. It's
+// also the name robots.txt rules have to use to single out this crawler.
+const DefaultUserAgent = "crawl/1.0"
+
+// How long we wait for a robots.txt before assuming there isn't one.
+const robotsTimeout = 10 * time.Second
+
+// How long we keep a site's robots.txt before fetching it again, and
+// how long we wait to try again when we couldn't get it: either the
+// fetch failed or the site had an error, which robots.txt says means
+// keep out, so that shouldn't last past a brief outage.
+const (
+	robotsTTL   = 24 * time.Hour
+	robotsRetry = time.Minute
+)
+
+// The most redirects we follow for one fetch.
+const maxRedirects = 10
+
+// Why we stopped following redirects. They come back from colly
+// wrapped up in a url.Error.
+var (
+	errRedirectLoop     = errors.New("redirect loop")
+	errTooManyRedirects = fmt.Errorf("more than %d redirects", maxRedirects)
+)
+
+// How long we wait when checking an asset.
+const assetTimeout = 30 * time.Second
+
+// Sitemap limits: how long we wait for one, how deep we follow sitemap
+// indexes, and the largest sitemap we'll read (the protocol's limit).
+const (
+	sitemapTimeout  = 30 * time.Second
+	sitemapMaxDepth = 5
+	sitemapMaxSize  = 50 * 1024 * 1024
+)
+
+// sitemapXML covers both kinds of sitemap file: a urlset, which
+// lists pages, and a sitemapindex, which lists more sitemaps.
+type sitemapXML struct {
+	URLs     []sitemapLoc `xml:"url"`
+	Sitemaps []sitemapLoc `xml:"sitemap"`
+}
+
+type sitemapLoc struct {
+	Loc string `xml:"loc"`
+}
+
 // Fetcher is a URL fetcher that takes a URL (as a string),
 // fetches the web page corresponding to it, and returns
-// a list of the URLs on the page (as strings) and any HTTP
-// error occurring while trying to follow the link, parse the HTML, etc.
+// what happened: the response's status, size and so on,
+// the links on the page, and any error occurring while
+// trying to follow the link, parse the HTML, etc.
 type Fetcher struct {
+	userAgent string
+	// robots.txt data for each site, keyed by scheme and host.
+	robots map[string]*robotsEntry
+	sync.Mutex
+}
+
+// robotsEntry is a site's robots.txt data. ready is closed once it's
+// been fetched; until then, anyone else who wants it waits for it.
+// After expires, it's fetched again.
+type robotsEntry struct {
+	ready   chan struct{}
+	data    *robotstxt.RobotsData
+	expires time.Time
 }
 
-// New creates a properly-initialized Fetcher.
-func New() *Fetcher {
-	f := Fetcher{}
+// New creates a properly-initialized Fetcher. The user agent is sent
+// with every request and selects the robots.txt rules we follow.
+func New(userAgent string) *Fetcher {
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+	f := Fetcher{
+		userAgent: userAgent,
+		robots:    make(map[string]*robotsEntry),
+	}
 	return &f
 }
 
-// Fetch actually does all the work.
-func (m *Fetcher) Fetch(URL string) (string, []string, error) {
+// Fetch actually does all the work. HTTP error statuses, and
+// failures to get a response at all, come back as the Result's Err.
+// Redirects are followed as long as they stay on the same host.
+func (m *Fetcher) Fetch(URL string) *page.Result {
 	u, err := url.Parse(URL)
-	links := []string{}
 	if err != nil {
-		return "", links, err
+		return &page.Result{
+			URL:      URL,
+			FinalURL: URL,
+			Err:      &page.Error{Kind: page.InvalidURL, Message: err.Error()},
+		}
+	}
+	return m.FetchWithin(URL, func(target string) bool {
+		t, err := url.Parse(target)
+		return err == nil && t.Host == u.Host
+	})
+}
+
+// FetchWithin fetches URL like Fetch, but only follows a redirect if
+// follow says the URL it goes to may be fetched. A redirect it doesn't
+// follow ends the fetch; the Result is the redirect itself, with its
+// 3xx status. Every redirect, followed or not, is in the Result.
+func (m *Fetcher) FetchWithin(URL string, follow func(url string) bool) *page.Result {
+	result := &page.Result{URL: URL, FinalURL: URL}
+	if _, err := url.Parse(URL); err != nil {
+		result.Err = &page.Error{Kind: page.InvalidURL, Message: err.Error()}
+		return result
 	}
 
-	var text string
+	var text, baseHref, canonical string
+	links := []page.Link{}
+	anchors := []string{}
+	seen := map[string]bool{}
+	meta := &page.Meta{}
 
-	// Set up scraper
+	// Set up scraper. We parse error pages too, so that we see
+	// their responses; the links on them are dropped below.
 	c := colly.NewCollector(
-		colly.AllowedDomains(u.Host),
+		colly.UserAgent(m.userAgent),
+		colly.ParseHTTPErrorResponse(),
 	)
+	// Record each redirect, and decide whether to follow it.
+	c.RedirectHandler = func(req *http.Request, via []*http.Request) error {
+		from := via[len(via)-1]
+		result.Redirects = append(result.Redirects, page.Redirect{
+			URL:      from.URL.String(),
+			Status:   req.Response.StatusCode,
+			Location: req.URL.String(),
+		})
+		for _, prev := range via {
+			if prev.URL.String() == req.URL.String() {
+				return errRedirectLoop
+			}
+		}
+		switch {
+		case len(via) >= maxRedirects:
+			return errTooManyRedirects
+		case follow != nil && !follow(req.URL.String()):
+			log.Debugf("REDIRECT> not following %s to %s", from.URL, req.URL)
+			return http.ErrUseLastResponse
+		}
+		return nil
+	}
 
 	// Capture all the body text
 	c.OnHTML("body", func(e *colly.HTMLElement) {
 		text = e.Text
 	})
-	// Extract links
+	// Note what the page says about itself.
+	c.OnHTML("html[lang]", func(e *colly.HTMLElement) {
+		meta.Language = e.Attr("lang")
+	})
+	c.OnHTML("head > title", func(e *colly.HTMLElement) {
+		if meta.Title == "" {
+			meta.Title = strings.Join(strings.Fields(e.Text), " ")
+		}
+	})
+	c.OnHTML("meta[name][content]", func(e *colly.HTMLElement) {
+		content := strings.Join(strings.Fields(e.Attr("content")), " ")
+		switch strings.ToLower(e.Attr("name")) {
+		case "description":
+			if meta.Description == "" {
+				meta.Description = content
+			}
+		case "robots":
+			if meta.Robots == "" {
+				meta.Robots = content
+			}
+		}
+	})
+	c.OnHTML("h1", func(e *colly.HTMLElement) {
+		meta.H1s = append(meta.H1s, strings.Join(strings.Fields(e.Text), " "))
+	})
+	// Links are relative to the first <base href>, if there is one
+	c.OnHTML("base[href]", func(e *colly.HTMLElement) {
+		if baseHref == "" {
+			baseHref = e.Attr("href")
+		}
+	})
+	// Extract links, and the text they're on
 	c.OnHTML("a[href]", func(e *colly.HTMLElement) {
-		links = append(links, e.Attr("href"))
+		links = append(links, page.Link{
+			URL:  e.Attr("href"),
+			Text: strings.Join(strings.Fields(e.Text), " "),
+		})
+	})
+	// Collect the anchors links can point at: any element's id, and
+	// the old-style <a name>.
+	c.OnHTML("[id], a[name]", func(e *colly.HTMLElement) {
+		for _, anchor := range []string{e.Attr("id"), e.Attr("name")} {
+			if anchor != "" && !seen[anchor] {
+				seen[anchor] = true
+				anchors = append(anchors, anchor)
+			}
+		}
+	})
+	// Extract the assets the page uses
+	for _, a := range assetAttrs {
+		a := a
+		c.OnHTML(a.selector, func(e *colly.HTMLElement) {
+			links = append(links, page.Link{URL: e.Attr(a.attr), Text: e.Attr("alt"), Asset: a.asset})
+		})
+	}
+	c.OnHTML("img[srcset], source[srcset]", func(e *colly.HTMLElement) {
+		for _, u := range srcset(e.Attr("srcset")) {
+			links = append(links, page.Link{URL: u, Text: e.Attr("alt"), Asset: page.Image})
+		}
+	})
+	c.OnHTML("link[href]", func(e *colly.HTMLElement) {
+		if asset := linkAsset(e.Attr("rel")); asset != "" {
+			links = append(links, page.Link{URL: e.Attr("href"), Asset: asset})
+		}
+		if canonical == "" && hasRel(e.Attr("rel"), "canonical") {
+			canonical = e.Attr("href")
+		}
 	})
 	// Log a debug message for each page visit
+	var began time.Time
 	c.OnRequest(func(r *colly.Request) {
 		log.Debugf("VISIT> %s", r.URL.String())
+		began = time.Now()
+	})
+	// Time each response's arrival; the last is the one we keep.
+	c.WithTransport(timedTransport{http.DefaultTransport, func() {
+		result.FirstByte = time.Since(began)
+	}})
+	// Record the response; its URL is where any redirects ended up.
+	c.OnResponse(func(r *colly.Response) {
+		result.Latency = time.Since(began)
+		result.Status = r.StatusCode
+		result.FinalURL = r.Request.URL.String()
+		result.ContentType = r.Headers.Get("Content-Type")
+		result.Size = len(r.Body)
 	})
 	// Actually do it.
-	c.Visit(URL)
+	err := c.Visit(URL)
+	if result.Latency == 0 && !began.IsZero() {
+		result.Latency = time.Since(began)
+	}
+
+	switch {
+	case err != nil:
+		result.Err = &page.Error{Kind: classify(err), Message: err.Error()}
+	case result.Status >= 400:
+		result.Err = &page.Error{
+			Kind:    page.HTTPError,
+			Message: fmt.Sprintf("%d %s", result.Status, http.StatusText(result.Status)),
+		}
+	case result.Status >= 300:
+		// A redirect we didn't follow. Its Location is in Redirects;
+		// any links on it are just to the same place.
+	default:
+		result.Body = text
+		result.Links = resolve(result.FinalURL, baseHref, links)
+		result.Anchors = anchors
+		result.Hash = page.ContentHash(text)
+		meta.Words = len(strings.Fields(text))
+		for _, link := range result.Links {
+			if link.Asset == "" {
+				meta.OutboundLinks++
+			}
+		}
+		result.Meta = meta
+		if canonical != "" {
+			result.Canonical = resolve(result.FinalURL, baseHref, []page.Link{{URL: canonical}})[0].URL
+		}
+	}
+	log.Debugf("FETCHED> %s: %d, %d bytes in %v (first byte %v)", URL, result.Status, result.Size, result.Latency, result.FirstByte)
+	return result
+}
+
+// timedTransport tells arrived when each response starts to arrive:
+// RoundTrip returns as soon as the headers are in, before the body
+// is read.
+type timedTransport struct {
+	http.RoundTripper
+	arrived func()
+}
+
+func (t timedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if err == nil {
+		t.arrived()
+	}
+	return resp, err
+}
+
+// assetAttrs are the elements and attributes that point at assets.
+var assetAttrs = []struct {
+	selector, attr string
+	asset          page.Asset
+}{
+	{"img[src]", "src", page.Image},
+	{"video[poster]", "poster", page.Image},
+	{"script[src]", "src", page.Script},
+	{"video[src]", "src", page.Media},
+	{"audio[src]", "src", page.Media},
+	{"source[src]", "src", page.Media},
+	{"iframe[src]", "src", page.Frame},
+}
+
+// srcset pulls the URLs out of a srcset attribute: a comma-separated
+// list of URLs, each optionally followed by a size.
+func srcset(attr string) []string {
+	urls := []string{}
+	for _, candidate := range strings.Split(attr, ",") {
+		if fields := strings.Fields(candidate); len(fields) > 0 {
+			urls = append(urls, fields[0])
+		}
+	}
+	return urls
+}
+
+// linkAsset works out from its rel what kind of asset a <link> is
+// to; empty for the kinds we don't check, like canonical links.
+func linkAsset(rel string) page.Asset {
+	for _, r := range strings.Fields(strings.ToLower(rel)) {
+		switch r {
+		case "stylesheet":
+			return page.Stylesheet
+		case "icon", "apple-touch-icon":
+			return page.Icon
+		}
+	}
+	return ""
+}
+
+// hasRel checks whether a rel attribute includes the given type.
+func hasRel(rel, want string) bool {
+	for _, r := range strings.Fields(strings.ToLower(rel)) {
+		if r == want {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve makes the links on a page absolute. They're relative to the
+// page's <base href>, if it has one -- which may itself be relative to
+// the page -- or else to the page's own URL, after any redirects.
+// Fragments are kept, so that the crawler can check them. A link that
+// can't be parsed is left as it is, for the crawler to report.
+func resolve(pageURL, baseHref string, links []page.Link) []page.Link {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return links
+	}
+	if baseHref = strings.TrimSpace(baseHref); baseHref != "" {
+		if b, err := base.Parse(baseHref); err == nil {
+			base = b
+		}
+	}
+	resolved := make([]page.Link, 0, len(links))
+	for _, link := range links {
+		if u, err := base.Parse(strings.TrimSpace(link.URL)); err == nil {
+			link.URL = u.String()
+		}
+		resolved = append(resolved, link)
+	}
+	return resolved
+}
+
+// classify works out what kind of error a failed request got.
+func classify(err error) page.ErrorKind {
+	if uerr, ok := err.(*url.Error); ok {
+		if uerr.Timeout() {
+			return page.Timeout
+		}
+		err = uerr.Err
+	}
+	switch err {
+	case errRedirectLoop:
+		return page.RedirectLoop
+	case errTooManyRedirects:
+		return page.TooManyRedirects
+	}
+	// A failed lookup comes wrapped up as a failed dial.
+	if operr, ok := err.(*net.OpError); ok {
+		if _, ok := operr.Err.(*net.DNSError); ok {
+			return page.DNSError
+		}
+	}
+	switch e := err.(type) {
+	case *net.DNSError:
+		return page.DNSError
+	case net.Error:
+		if e.Timeout() {
+			return page.Timeout
+		}
+		return page.ConnectionError
+	}
+	return page.OtherError
+}
+
+// Check makes sure an asset can be fetched, without parsing it. It
+// asks with HEAD, and falls back to GET for servers that won't answer
+// a HEAD; a GET reads the body to find its size.
+func (m *Fetcher) Check(URL string) *page.Result {
+	result := &page.Result{URL: URL, FinalURL: URL}
+	began := time.Now()
+	defer func() {
+		result.Latency = time.Since(began)
+	}()
+
+	resp, err := m.request("HEAD", URL)
+	if err == nil && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented) {
+		resp.Body.Close()
+		resp, err = m.request("GET", URL)
+	}
+	if err != nil {
+		result.Err = &page.Error{Kind: classify(err), Message: err.Error()}
+		if _, bad := err.(badRequest); bad {
+			result.Err.Kind = page.InvalidURL
+		}
+		return result
+	}
+	defer resp.Body.Close()
+	result.FirstByte = time.Since(began)
+	log.Debugf("CHECK> %s %s: %s", resp.Request.Method, URL, resp.Status)
+
+	result.Status = resp.StatusCode
+	result.FinalURL = resp.Request.URL.String()
+	result.ContentType = resp.Header.Get("Content-Type")
+	if resp.ContentLength >= 0 {
+		result.Size = int(resp.ContentLength)
+	}
+	if resp.Request.Method == "GET" {
+		n, _ := io.Copy(ioutil.Discard, resp.Body)
+		result.Size = int(n)
+	}
+	if resp.StatusCode >= 400 {
+		result.Err = &page.Error{Kind: page.HTTPError, Message: resp.Status}
+	}
+	return result
+}
+
+// badRequest is an error making a request, rather than sending it.
+type badRequest struct {
+	error
+}
+
+// request sends a request for an asset.
+func (m *Fetcher) request(method, URL string) (*http.Response, error) {
+	req, err := http.NewRequest(method, URL, nil)
+	if err != nil {
+		return nil, badRequest{err}
+	}
+	req.Header.Set("User-Agent", m.userAgent)
+	client := http.Client{Timeout: assetTimeout}
+	return client.Do(req)
+}
+
+// Allowed checks URL against its site's robots.txt, and returns
+// whether we may fetch it and the Crawl-delay the site asks for.
+// A URL we can't make sense of is allowed; Fetch will report
+// the problem with it.
+func (m *Fetcher) Allowed(URL string) (bool, time.Duration) {
+	u, err := url.Parse(URL)
+	if err != nil || u.Host == "" {
+		return true, 0
+	}
+	robots := m.robotsFor(u)
+	group := robots.FindGroup(m.userAgent)
+	return robots.TestAgent(u.RequestURI(), m.userAgent), group.CrawlDelay
+}
+
+// robotsFor returns the robots.txt data for the site u is on, fetching
+// it the first time the site is seen, and again once what we have
+// expires. The fetch happens without the lock held, so a slow site
+// only holds up the requests for that site.
+func (m *Fetcher) robotsFor(u *url.URL) *robotstxt.RobotsData {
+	site := u.Scheme + "://" + u.Host
+
+	m.Lock()
+	entry, ok := m.robots[site]
+	if ok && !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		// Stale; anyone still reading the old entry can finish with it.
+		ok = false
+	}
+	if !ok {
+		entry = &robotsEntry{ready: make(chan struct{})}
+		m.robots[site] = entry
+	}
+	m.Unlock()
+	if ok {
+		<-entry.ready
+		return entry.data
+	}
+
+	ttl := robotsTTL
+	robots, status, err := m.fetchRobots(site + "/robots.txt")
+	if err != nil {
+		// No usable robots.txt; treat it as missing, which allows everything.
+		log.Debugf("no robots.txt for %s: %s", site, err.Error())
+		robots, _ = robotstxt.FromStatusAndBytes(http.StatusNotFound, nil)
+	}
+	if err != nil || status >= 500 {
+		ttl = robotsRetry
+	}
+	m.Lock()
+	entry.data = robots
+	entry.expires = time.Now().Add(ttl)
+	m.Unlock()
+	close(entry.ready)
+	return robots
+}
+
+// fetchRobots gets and parses a robots.txt. The status code matters:
+// robotstxt treats a 4xx as "no rules" and a 5xx as "keep out".
+// It returns the response's status along with the data.
+func (m *Fetcher) fetchRobots(robotsURL string) (*robotstxt.RobotsData, int, error) {
+	req, err := http.NewRequest("GET", robotsURL, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("User-Agent", m.userAgent)
+	client := http.Client{Timeout: robotsTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	log.Debugf("ROBOTS> %s: %s", robotsURL, resp.Status)
+	robots, err := robotstxt.FromResponse(resp)
+	return robots, resp.StatusCode, err
+}
 
-	return string(text), links, nil
+// Sitemap finds the sitemaps for the site URL is on -- /sitemap.xml and
+// any listed in its robots.txt -- and returns every page they list.
+// Sitemap indexes are followed down to the sitemaps they list.
+func (m *Fetcher) Sitemap(URL string) ([]string, error) {
+	u, err := url.Parse(URL)
+	if err != nil {
+		return nil, err
+	}
+	site := u.Scheme + "://" + u.Host
+	sitemaps := append([]string{site + "/sitemap.xml"}, m.robotsFor(u).Sitemaps...)
+
+	seen := make(map[string]bool)
+	pages := []string{}
+	for _, sitemap := range sitemaps {
+		pages = m.readSitemap(sitemap, 0, seen, pages)
+	}
+	log.Debugf("SITEMAP> %d pages for %s", len(pages), site)
+	return pages, nil
+}
+
+// readSitemap adds the pages listed in a sitemap to pages, and recurses
+// into the sitemaps listed in a sitemap index. A sitemap that can't be
+// read is skipped. seen keeps us from reading any sitemap twice, and from
+// listing any page twice.
+func (m *Fetcher) readSitemap(sitemap string, depth int, seen map[string]bool, pages []string) []string {
+	if seen[sitemap] || depth > sitemapMaxDepth {
+		return pages
+	}
+	seen[sitemap] = true
+
+	parsed, err := m.fetchSitemap(sitemap)
+	if err != nil {
+		log.Debugf("skipping sitemap %s: %s", sitemap, err.Error())
+		return pages
+	}
+	for _, page := range parsed.URLs {
+		loc := strings.TrimSpace(page.Loc)
+		if loc != "" && !seen[loc] {
+			seen[loc] = true
+			pages = append(pages, loc)
+		}
+	}
+	for _, index := range parsed.Sitemaps {
+		pages = m.readSitemap(strings.TrimSpace(index.Loc), depth+1, seen, pages)
+	}
+	return pages
+}
+
+// fetchSitemap gets and parses a single sitemap file, which may be gzipped.
+func (m *Fetcher) fetchSitemap(sitemap string) (*sitemapXML, error) {
+	req, err := http.NewRequest("GET", sitemap, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", m.userAgent)
+	client := http.Client{Timeout: sitemapTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	log.Debugf("SITEMAP> %s: %s", sitemap, resp.Status)
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New(resp.Status)
+	}
+
+	var body io.Reader = io.LimitReader(resp.Body, sitemapMaxSize)
+	if strings.HasSuffix(sitemap, ".gz") {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		body = io.LimitReader(gz, sitemapMaxSize)
+	}
+	var parsed sitemapXML
+	if err := xml.NewDecoder(body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+	return &parsed, nil
 }
@@ -0,0 +1,333 @@
+package Fetcher
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joemcmahon/joe_macmahon_technical_test/crawler/page"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// urls pulls the URLs out of a list of links.
+func urls(links []page.Link) []string {
+	list := []string{}
+	for _, link := range links {
+		list = append(list, link.URL)
+	}
+	return list
+}
+
+var _ = Describe("fetcher", func() {
+	Context("resolving links", func() {
+		links := []page.Link{
+			{URL: "page2.html"},
+			{URL: "../about.html"},
+			{URL: "?page=3"},
+			{URL: "/root.html"},
+			{URL: "//other.example.com/x"},
+			{URL: "http://example.org/abs#frag"},
+			{URL: "#top"},
+		}
+		It("resolves them against the page", func() {
+			Expect(urls(resolve("https://example.com/docs/intro.html", "", links))).To(Equal([]string{
+				"https://example.com/docs/page2.html",
+				"https://example.com/about.html",
+				"https://example.com/docs/intro.html?page=3",
+				"https://example.com/root.html",
+				"https://other.example.com/x",
+				"http://example.org/abs#frag",
+				"https://example.com/docs/intro.html#top",
+			}))
+		})
+		It("resolves them against the base href, relative to the page", func() {
+			Expect(urls(resolve("https://example.com/docs/intro.html", "/v2/", links[:2]))).To(Equal([]string{
+				"https://example.com/v2/page2.html",
+				"https://example.com/about.html",
+			}))
+		})
+		It("leaves links it can't parse alone", func() {
+			Expect(urls(resolve("https://example.com/", "", []page.Link{{URL: "http://[::1"}}))).To(Equal([]string{"http://[::1"}))
+		})
+	})
+	Context("robots.txt", func() {
+		It("doesn't hold up other sites while one is slow", func() {
+			var requests int32
+			arrived, release := make(chan bool, 10), make(chan bool)
+			slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				arrived <- true
+				select {
+				case <-release:
+				case <-time.After(5 * time.Second):
+				}
+				fmt.Fprint(w, "User-agent: *\nDisallow: /private/\n")
+			}))
+			defer slow.Close()
+			fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, "User-agent: *\nDisallow: /secret/\n")
+			}))
+			defer fast.Close()
+
+			f := New("")
+			answers := make(chan bool, 3)
+			for i := 0; i < 3; i++ {
+				go func() {
+					allowed, _ := f.Allowed(slow.URL + "/private/page.html")
+					answers <- allowed
+				}()
+			}
+			Eventually(arrived).Should(Receive())
+
+			// The slow site's robots.txt is still on its way.
+			fastAnswer := make(chan bool, 1)
+			go func() {
+				allowed, _ := f.Allowed(fast.URL + "/secret/page.html")
+				fastAnswer <- allowed
+			}()
+			Eventually(fastAnswer).Should(Receive(BeFalse()))
+			Consistently(answers).ShouldNot(Receive())
+
+			close(release)
+			for i := 0; i < 3; i++ {
+				Eventually(answers).Should(Receive(BeFalse()))
+			}
+			Expect(atomic.LoadInt32(&requests)).To(Equal(int32(1)))
+		})
+		It("tries again soon after a site's error", func() {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&requests, 1) == 1 {
+					http.Error(w, "down for maintenance", http.StatusServiceUnavailable)
+					return
+				}
+				fmt.Fprint(w, "User-agent: *\nDisallow: /private/\n")
+			}))
+			defer server.Close()
+
+			f := New("")
+			// A 5xx means keep out, for now.
+			allowed, _ := f.Allowed(server.URL + "/page.html")
+			Expect(allowed).To(BeFalse())
+			entry := f.robots[server.URL]
+			Expect(entry.expires).To(BeTemporally("<=", time.Now().Add(robotsRetry)))
+
+			entry.expires = time.Now().Add(-time.Second)
+			allowed, _ = f.Allowed(server.URL + "/page.html")
+			Expect(allowed).To(BeTrue())
+			allowed, _ = f.Allowed(server.URL + "/private/page.html")
+			Expect(allowed).To(BeFalse())
+			Expect(atomic.LoadInt32(&requests)).To(Equal(int32(2)))
+			Expect(f.robots[server.URL].expires).To(BeTemporally(">", time.Now().Add(robotsRetry)))
+		})
+		It("tries again soon when it can't get robots.txt", func() {
+			server := httptest.NewServer(http.NotFoundHandler())
+			site := server.URL
+			server.Close()
+
+			f := New("")
+			allowed, _ := f.Allowed(site + "/page.html")
+			Expect(allowed).To(BeTrue())
+			Expect(f.robots[site].expires).To(BeTemporally("<=", time.Now().Add(robotsRetry)))
+		})
+	})
+	Context("fetching", func() {
+		var server *httptest.Server
+		BeforeEach(func() {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/docs/", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `<html><head><base href="/v2/"></head>
+					<body><a href="guide.html">The  guide</a> <a href="../up.html">Up</a></body></html>`)
+			})
+			mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "/blog/post.html", http.StatusMovedPermanently)
+			})
+			mux.HandleFunc("/blog/post.html", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `<html><body><h1 id="title">Post</h1><a name="old"></a>
+					<p id="title">Again</p><a href="next.html#top">Next</a></body></html>`)
+			})
+			mux.HandleFunc("/copy", func(w http.ResponseWriter, r *http.Request) {
+				// The same text, laid out differently.
+				text := "Same text"
+				if r.URL.Query().Get("spaced") != "" {
+					text = "\n  Same \t text\n"
+				}
+				fmt.Fprintf(w, `<html><head><link rel="Canonical" href="blog/post.html"></head><body>%s</body></html>`, text)
+			})
+			mux.HandleFunc("/about.html", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `<html lang="en-GB"><head><title> About
+					us </title><meta name="Description" content="Who we are."><meta name="robots" content="noindex, follow"></head>
+					<body><h1>About</h1> <svg><title>Logo</title></svg> <h1>Us  too</h1>
+					<p>Three more words <a href="/">Home</a> <a href="/team.html">Team</a> <img src="us.jpg"></p></body></html>`)
+			})
+			mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+				// Slow to answer, and then slow to finish.
+				time.Sleep(50 * time.Millisecond)
+				fmt.Fprint(w, "<html><body>")
+				w.(http.Flusher).Flush()
+				time.Sleep(50 * time.Millisecond)
+				fmt.Fprint(w, "Done</body></html>")
+			})
+			mux.HandleFunc("/hop", func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "/moved", http.StatusFound)
+			})
+			mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "/loop2", http.StatusFound)
+			})
+			mux.HandleFunc("/loop2", func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "/loop", http.StatusFound)
+			})
+			mux.HandleFunc("/forever/", func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, r.URL.Path+"x", http.StatusFound)
+			})
+			mux.HandleFunc("/away", func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "http://elsewhere.example.com/", http.StatusMovedPermanently)
+			})
+			mux.HandleFunc("/assets.html", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `<html><head><link rel="stylesheet" href="/site.css"><link rel="alternate" href="/feed.xml"></head>
+					<body><img src="logo.png" alt="Logo"> <img srcset="small.jpg 1x, large.jpg 2x">
+					<script src="/app.js"></script> <a href="/next.html">Next</a></body></html>`)
+			})
+			mux.HandleFunc("/site.css", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/css")
+				fmt.Fprint(w, "body {}")
+			})
+			mux.HandleFunc("/no-head.png", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == "HEAD" {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+				fmt.Fprint(w, "PNG data")
+			})
+			server = httptest.NewServer(mux)
+		})
+		AfterEach(func() {
+			server.Close()
+		})
+		It("returns absolute links, using the base href", func() {
+			result := New("").Fetch(server.URL + "/docs/")
+			Expect(result.Err).To(BeNil())
+			Expect(result.Status).To(Equal(http.StatusOK))
+			Expect(result.Links).To(Equal([]page.Link{
+				{URL: server.URL + "/v2/guide.html", Text: "The guide"},
+				{URL: server.URL + "/up.html", Text: "Up"},
+			}))
+		})
+		It("resolves links against where a redirect ended up", func() {
+			result := New("").Fetch(server.URL + "/moved")
+			Expect(result.FinalURL).To(Equal(server.URL + "/blog/post.html"))
+			Expect(urls(result.Links)).To(Equal([]string{server.URL + "/blog/next.html#top"}))
+		})
+		It("collects the anchors on the page", func() {
+			result := New("").Fetch(server.URL + "/blog/post.html")
+			Expect(result.Anchors).To(ConsistOf("title", "old"))
+		})
+		It("reads the canonical URL and fingerprints the text", func() {
+			plain := New("").Fetch(server.URL + "/copy")
+			spaced := New("").Fetch(server.URL + "/copy?spaced=1")
+			Expect(plain.Canonical).To(Equal(server.URL + "/blog/post.html"))
+			Expect(plain.Hash).To(Equal(page.ContentHash("Same text")))
+			Expect(spaced.Hash).To(Equal(plain.Hash))
+			other := New("").Fetch(server.URL + "/blog/post.html")
+			Expect(other.Canonical).To(BeEmpty())
+			Expect(other.Hash).ToNot(Equal(plain.Hash))
+		})
+		It("reads what the page says about itself", func() {
+			result := New("").Fetch(server.URL + "/about.html")
+			Expect(result.Meta).To(Equal(&page.Meta{
+				Title:         "About us",
+				Description:   "Who we are.",
+				H1s:           []string{"About", "Us too"},
+				Robots:        "noindex, follow",
+				Language:      "en-GB",
+				Words:         9,
+				OutboundLinks: 2,
+			}))
+		})
+		It("times the fetch and the first byte", func() {
+			result := New("").Fetch(server.URL + "/slow")
+			Expect(result.FirstByte).To(BeNumerically(">=", 50*time.Millisecond))
+			Expect(result.Latency).To(BeNumerically(">=", 100*time.Millisecond))
+			Expect(result.FirstByte).To(BeNumerically("<", result.Latency))
+			Expect(result.Size).To(Equal(len("<html><body>Done</body></html>")))
+		})
+		It("records each redirect", func() {
+			result := New("").Fetch(server.URL + "/hop")
+			Expect(result.Err).To(BeNil())
+			Expect(result.FinalURL).To(Equal(server.URL + "/blog/post.html"))
+			Expect(result.Redirects).To(Equal([]page.Redirect{
+				{URL: server.URL + "/hop", Status: http.StatusFound, Location: server.URL + "/moved"},
+				{URL: server.URL + "/moved", Status: http.StatusMovedPermanently, Location: server.URL + "/blog/post.html"},
+			}))
+		})
+		It("stops at redirects it's told not to follow", func() {
+			result := New("").FetchWithin(server.URL+"/hop", func(target string) bool {
+				return target != server.URL+"/moved"
+			})
+			Expect(result.Err).To(BeNil())
+			Expect(result.Status).To(Equal(http.StatusFound))
+			Expect(result.FinalURL).To(Equal(server.URL + "/hop"))
+			Expect(result.Redirects).To(HaveLen(1))
+			Expect(result.Links).To(BeEmpty())
+		})
+		It("doesn't follow redirects off the host", func() {
+			result := New("").Fetch(server.URL + "/away")
+			Expect(result.Status).To(Equal(http.StatusMovedPermanently))
+			Expect(result.Redirects[0].Location).To(Equal("http://elsewhere.example.com/"))
+		})
+		It("detects redirect loops", func() {
+			result := New("").Fetch(server.URL + "/loop")
+			Expect(result.Err.Kind).To(Equal(page.RedirectLoop))
+			Expect(result.Redirects).To(HaveLen(2))
+		})
+		It("gives up on long chains", func() {
+			result := New("").Fetch(server.URL + "/forever/")
+			Expect(result.Err.Kind).To(Equal(page.TooManyRedirects))
+			Expect(result.Redirects).To(HaveLen(maxRedirects))
+		})
+		It("reports HTTP errors", func() {
+			result := New("").Fetch(server.URL + "/missing")
+			Expect(result.Status).To(Equal(http.StatusNotFound))
+			Expect(result.Err.Kind).To(Equal(page.HTTPError))
+			Expect(result.Links).To(BeEmpty())
+		})
+		It("finds the assets a page uses", func() {
+			result := New("").Fetch(server.URL + "/assets.html")
+			Expect(result.Links).To(ConsistOf(
+				page.Link{URL: server.URL + "/site.css", Asset: page.Stylesheet},
+				page.Link{URL: server.URL + "/logo.png", Text: "Logo", Asset: page.Image},
+				page.Link{URL: server.URL + "/small.jpg", Asset: page.Image},
+				page.Link{URL: server.URL + "/large.jpg", Asset: page.Image},
+				page.Link{URL: server.URL + "/app.js", Asset: page.Script},
+				page.Link{URL: server.URL + "/next.html", Text: "Next"},
+			))
+		})
+		It("checks assets without parsing them", func() {
+			result := New("").Check(server.URL + "/site.css")
+			Expect(result.Err).To(BeNil())
+			Expect(result.Status).To(Equal(http.StatusOK))
+			Expect(result.ContentType).To(Equal("text/css"))
+			Expect(result.Size).To(Equal(len("body {}")))
+			Expect(result.Body).To(BeEmpty())
+		})
+		It("falls back to GET if HEAD isn't allowed", func() {
+			result := New("").Check(server.URL + "/no-head.png")
+			Expect(result.Status).To(Equal(http.StatusOK))
+			Expect(result.Size).To(Equal(len("PNG data")))
+		})
+		It("reports missing assets", func() {
+			result := New("").Check(server.URL + "/missing.png")
+			Expect(result.Status).To(Equal(http.StatusNotFound))
+			Expect(result.Err.Kind).To(Equal(page.HTTPError))
+		})
+	})
+})
+
+func TestThings(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Fetcher Suite")
+}
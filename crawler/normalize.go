@@ -0,0 +1,157 @@
+package crawler
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/PuerkitoBio/purell"
+)
+
+// FlagSet is a set of purell normalizations.
+type FlagSet int
+
+const (
+	// DefaultFlags is every normalization purell has, except the ones
+	// that can take a URL to a different page or site: removing
+	// index.html, forcing http, and adding "www.".
+	DefaultFlags FlagSet = iota
+	// SafeFlags only makes changes that never change the page a URL
+	// is for: the case of the scheme and host, escapes, default ports.
+	SafeFlags
+	// UsuallySafeFlags also removes dot segments ("/a/./b/../c").
+	UsuallySafeFlags
+	// UnsafeFlags also removes index.html, duplicate slashes and
+	// "www.", and sorts the query.
+	UnsafeFlags
+)
+
+var flagSetNames = map[FlagSet]string{
+	DefaultFlags:     "default",
+	SafeFlags:        "safe",
+	UsuallySafeFlags: "usually safe",
+	UnsafeFlags:      "unsafe",
+}
+
+func (f FlagSet) String() string {
+	return flagSetNames[f]
+}
+
+var flagSets = map[FlagSet]purell.NormalizationFlags{
+	DefaultFlags:     purell.FlagsAllNonGreedy &^ purell.FlagRemoveDirectoryIndex &^ purell.FlagForceHTTP &^ purell.FlagAddWWW,
+	SafeFlags:        purell.FlagsSafe,
+	UsuallySafeFlags: purell.FlagsUsuallySafeNonGreedy,
+	UnsafeFlags:      purell.FlagsUnsafeGreedy &^ purell.FlagForceHTTP,
+}
+
+// The trailing slash is up to KeepTrailingSlash, whatever the flags.
+const slashFlags = purell.FlagAddTrailingSlash | purell.FlagRemoveTrailingSlash
+
+// Normalization says how URLs are normalized before they're crawled,
+// so that the different ways of writing a page's URL lead to the one
+// page. The zero Normalization applies DefaultFlags, and adds a
+// trailing slash to every page's path that doesn't name a file.
+type Normalization struct {
+	Flags FlagSet
+	// StripParams are query parameters to drop, like tracking and
+	// session ids. A name ending in "*" drops every parameter whose
+	// name starts with the rest of it ("utm_*").
+	StripParams []string
+	// SortQuery sorts the query parameters by name, so their order
+	// doesn't matter. DefaultFlags and UnsafeFlags always do.
+	SortQuery bool
+	// LowercasePaths lowercases paths, for sites where case
+	// doesn't matter.
+	LowercasePaths bool
+	// KeepTrailingSlash treats "/a" and "/a/" as different pages.
+	KeepTrailingSlash bool
+}
+
+// Normalize normalizes the URL of a page by policy n.
+func Normalize(URL string, n Normalization) (string, error) {
+	return n.normalize(URL, false)
+}
+
+// normalize normalizes a URL. An asset, or a page whose path ends in
+// a file name ("page2.html"), never gets a trailing slash added: it's
+// a file, not a directory, and a server would answer "page2.html/"
+// with a 404.
+func (n Normalization) normalize(URL string, asset bool) (string, error) {
+	u, err := url.Parse(URL)
+	if err != nil {
+		return "", err
+	}
+	if len(n.StripParams) > 0 && u.RawQuery != "" {
+		u.RawQuery = n.strip(u.RawQuery)
+	}
+	if n.LowercasePaths {
+		u.Path, u.RawPath = strings.ToLower(u.Path), strings.ToLower(u.RawPath)
+	}
+
+	// A fragment is a place on a page, not a page.
+	flags := flagSets[n.Flags]&^slashFlags | purell.FlagRemoveFragment
+	if n.SortQuery {
+		flags |= purell.FlagSortQuery
+	}
+	if !n.KeepTrailingSlash && !asset && !isFile(u.Path) {
+		flags |= purell.FlagAddTrailingSlash
+	}
+	return purell.NormalizeURLString(u.String(), flags)
+}
+
+// strip drops the StripParams from a query, leaving the rest as they
+// were, in the same order.
+func (n Normalization) strip(query string) string {
+	kept := []string{}
+	for _, param := range strings.Split(query, "&") {
+		name := strings.SplitN(param, "=", 2)[0]
+		if unescaped, err := url.QueryUnescape(name); err == nil {
+			name = unescaped
+		}
+		if !n.stripped(name) {
+			kept = append(kept, param)
+		}
+	}
+	return strings.Join(kept, "&")
+}
+
+func (n Normalization) stripped(name string) bool {
+	for _, strip := range n.StripParams {
+		if strings.HasSuffix(strip, "*") {
+			if strings.HasPrefix(name, strings.TrimSuffix(strip, "*")) {
+				return true
+			}
+		} else if name == strip {
+			return true
+		}
+	}
+	return false
+}
+
+// fileExtensions are the extensions that make the last segment of a
+// path a file name. Anything else with a dot in it ("/v1.2",
+// "/jquery.min", "/user/jane.doe") is taken to be a directory.
+var fileExtensions = map[string]bool{}
+
+func init() {
+	for _, ext := range strings.Fields(`
+		html htm xhtml shtml php asp aspx jsp jspx cfm cgi pl py rb
+		txt md xml json rss atom csv pdf rtf epub
+		doc docx xls xlsx ppt pptx odt ods odp
+		css js mjs map wasm woff woff2 ttf otf eot
+		png jpg jpeg gif svg webp avif ico bmp tif tiff
+		mp3 mp4 m4a m4v webm ogg ogv oga wav flac mov avi swf
+		zip gz tgz tar bz2 xz 7z rar dmg exe msi deb rpm apk iso`) {
+		fileExtensions[ext] = true
+	}
+}
+
+// isFile checks whether the last segment of a path is a file name:
+// it ends in one of the fileExtensions.
+func isFile(p string) bool {
+	if strings.HasSuffix(p, "/") {
+		return false
+	}
+	ext := strings.TrimPrefix(path.Ext(p), ".")
+	return fileExtensions[strings.ToLower(ext)]
+}
@@ -0,0 +1,82 @@
+package crawler
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("normalization", func() {
+	normal := func(n Normalization, URL string) string {
+		u, err := Normalize(URL, n)
+		Expect(err).ToNot(HaveOccurred())
+		return u
+	}
+	Context("by default", func() {
+		It("normalizes as it always has", func() {
+			n := Normalization{}
+			Expect(normal(n, "HTTP://Example.COM:80/a/./b/../c#top")).To(Equal("http://example.com/a/c/"))
+			Expect(normal(n, "http://example.com/a?b=2&a=1")).To(Equal("http://example.com/a/?a=1&b=2"))
+		})
+		It("doesn't add a trailing slash to file names", func() {
+			n := Normalization{}
+			Expect(normal(n, "http://example.com/a/index.html")).To(Equal("http://example.com/a/index.html"))
+			Expect(normal(n, "http://example.com/docs/page2.html?b=2&a=1#top")).To(Equal("http://example.com/docs/page2.html?a=1&b=2"))
+			Expect(normal(n, "http://example.com/v1.2/")).To(Equal("http://example.com/v1.2/"))
+			Expect(normal(n, "http://example.com/a/..")).To(Equal("http://example.com/"))
+			Expect(normal(n, "http://example.com/files/Report.PDF")).To(Equal("http://example.com/files/Report.PDF"))
+		})
+		It("adds a trailing slash to versioned and dotted directory names", func() {
+			n := Normalization{}
+			for _, dir := range []string{"/v1.2", "/lib/jquery.min", "/user/jane.doe", "/.well-known"} {
+				Expect(normal(n, "http://example.com"+dir)).To(Equal("http://example.com" + dir + "/"))
+				Expect(normal(n, "http://example.com"+dir+"/")).To(Equal("http://example.com" + dir + "/"))
+			}
+			n.Flags = UnsafeFlags
+			Expect(normal(n, "http://example.com/v1.2")).To(Equal("http://example.com/v1.2/"))
+		})
+		It("doesn't add a trailing slash to assets", func() {
+			u, err := Normalization{}.normalize("http://example.com/logo.png", true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(u).To(Equal("http://example.com/logo.png"))
+		})
+	})
+	Context("flag sets", func() {
+		It("only makes safe changes with SafeFlags", func() {
+			n := Normalization{Flags: SafeFlags}
+			Expect(normal(n, "HTTP://Example.COM:80/a/./b?b=2&a=1#top")).To(Equal("http://example.com/a/./b/?b=2&a=1"))
+		})
+		It("removes dot segments with UsuallySafeFlags", func() {
+			n := Normalization{Flags: UsuallySafeFlags}
+			Expect(normal(n, "http://example.com/a/./b/../c?b=2&a=1")).To(Equal("http://example.com/a/c/?b=2&a=1"))
+		})
+		It("goes further with UnsafeFlags", func() {
+			n := Normalization{Flags: UnsafeFlags}
+			Expect(normal(n, "https://www.example.com//a/index.html?b=2&a=1")).To(Equal("https://example.com/a/?a=1&b=2"))
+		})
+	})
+	Context("query parameters", func() {
+		It("strips the listed parameters", func() {
+			n := Normalization{Flags: SafeFlags, StripParams: []string{"utm_*", "sessionid", "fbclid"}}
+			Expect(normal(n, "http://example.com/a?utm_source=x&q=go&sessionid=1&utm_medium=y&fbclid=z&p=2")).To(Equal("http://example.com/a/?q=go&p=2"))
+			Expect(normal(n, "http://example.com/a?utm_source=x")).To(Equal("http://example.com/a/"))
+			Expect(normal(n, "http://example.com/a?sessionids=1")).To(Equal("http://example.com/a/?sessionids=1"))
+		})
+		It("sorts the query if asked", func() {
+			n := Normalization{Flags: SafeFlags, SortQuery: true}
+			Expect(normal(n, "http://example.com/a?b=2&a=1")).To(Equal("http://example.com/a/?a=1&b=2"))
+		})
+	})
+	Context("paths", func() {
+		It("lowercases paths if asked", func() {
+			n := Normalization{LowercasePaths: true}
+			Expect(normal(n, "http://example.com/About/Us?Q=Go")).To(Equal("http://example.com/about/us/?Q=Go"))
+		})
+		It("can keep trailing slashes as they are", func() {
+			n := Normalization{KeepTrailingSlash: true}
+			Expect(normal(n, "http://example.com/a")).To(Equal("http://example.com/a"))
+			Expect(normal(n, "http://example.com/a/")).To(Equal("http://example.com/a/"))
+			n.Flags = UnsafeFlags
+			Expect(normal(n, "http://example.com/a/")).To(Equal("http://example.com/a/"))
+		})
+	})
+})
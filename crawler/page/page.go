@@ -0,0 +1,156 @@
+// Package page holds the types that describe a fetched page. They are
+// shared by the crawler and the Fetchers, which can't import each other.
+package page
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// Link is a link found on a page: where it goes, and the text of the
+// anchor it's on.
+type Link struct {
+	URL  string
+	Text string
+	// Asset is the kind of asset the link is to -- an image, a
+	// script, and so on -- or empty if it's a link to a page.
+	Asset Asset `json:",omitempty"`
+}
+
+// Asset is a kind of thing a page uses, rather than links to.
+type Asset string
+
+// The kinds of asset.
+const (
+	Image      Asset = "image"
+	Script     Asset = "script"
+	Stylesheet Asset = "stylesheet"
+	Icon       Asset = "icon"
+	Media      Asset = "media"
+	Frame      Asset = "frame"
+)
+
+// Result is what a Fetcher found out when it fetched a URL. A failed
+// fetch still fills in whatever it can; a 404, for instance, has its
+// status, size and latency.
+type Result struct {
+	// URL is the URL we were asked to fetch.
+	URL string
+	// FinalURL is where we ended up, after any redirects.
+	FinalURL    string
+	Status      int
+	ContentType string
+	// Size is the size of the response body, in bytes.
+	Size int
+	// Latency is how long the whole fetch took, and FirstByte how
+	// long before the response (after any redirects) started to
+	// arrive.
+	Latency   time.Duration
+	FirstByte time.Duration `json:",omitempty"`
+	// Body is the text of the page, and Links the links on it.
+	// Only filled in for a successful fetch.
+	Body  string `json:",omitempty"`
+	Links []Link `json:",omitempty"`
+	// Canonical is the URL the page's <link rel="canonical"> names,
+	// if it has one.
+	Canonical string `json:",omitempty"`
+	// Hash is a fingerprint of the page's text; see ContentHash.
+	Hash string `json:",omitempty"`
+	// Meta is what the page says about itself. Only filled in for a
+	// successful fetch.
+	Meta *Meta `json:",omitempty"`
+	// Redirects are the redirects the fetch went through, in order;
+	// the last one's Location is FinalURL, unless it wasn't followed.
+	Redirects []Redirect `json:",omitempty"`
+	// Anchors are the fragments links can point at on the page: its
+	// ids and <a name>s.
+	Anchors []string `json:",omitempty"`
+	// Err is nil if the fetch succeeded.
+	Err *Error `json:",omitempty"`
+}
+
+// Meta is what a page says about itself: the things a search engine
+// looks at.
+type Meta struct {
+	Title       string   `json:",omitempty"`
+	Description string   `json:",omitempty"`
+	H1s         []string `json:",omitempty"`
+	// Robots is the content of the page's <meta name="robots">.
+	Robots string `json:",omitempty"`
+	// Language is the page's <html lang>.
+	Language string `json:",omitempty"`
+	// Words is the number of words in the page's text, and
+	// OutboundLinks the number of links on it to other pages.
+	Words         int
+	OutboundLinks int
+}
+
+// ContentHash is a fingerprint of a page's text, for spotting pages
+// with the same content. Differences in whitespace don't count. Text
+// with nothing in it has no fingerprint.
+func ContentHash(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// Redirect is one hop in a chain of redirects: the URL we asked for,
+// the status it answered with, and where it sent us.
+type Redirect struct {
+	URL      string
+	Status   int
+	Location string
+}
+
+// ErrorKind says what sort of thing went wrong with a fetch.
+type ErrorKind int
+
+const (
+	// OtherError is anything we couldn't classify.
+	OtherError ErrorKind = iota
+	// HTTPError means the server answered with an error status.
+	HTTPError
+	// DNSError means the host name couldn't be looked up.
+	DNSError
+	// ConnectionError means we couldn't connect, or lost the connection.
+	ConnectionError
+	// Timeout means the server took too long to answer.
+	Timeout
+	// InvalidURL means the URL couldn't be parsed or can't be fetched.
+	InvalidURL
+	// RedirectLoop means the redirects came back to a URL they'd
+	// already been through.
+	RedirectLoop
+	// TooManyRedirects means we gave up on a chain of redirects.
+	TooManyRedirects
+)
+
+var kindNames = map[ErrorKind]string{
+	OtherError:       "error",
+	HTTPError:        "HTTP error",
+	DNSError:         "DNS error",
+	ConnectionError:  "connection error",
+	Timeout:          "timeout",
+	InvalidURL:       "invalid URL",
+	RedirectLoop:     "redirect loop",
+	TooManyRedirects: "too many redirects",
+}
+
+func (k ErrorKind) String() string {
+	return kindNames[k]
+}
+
+// Error is why a fetch failed.
+type Error struct {
+	Kind    ErrorKind
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
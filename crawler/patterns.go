@@ -0,0 +1,95 @@
+package crawler
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Patterns limit a crawl to part of a site. Each pattern is matched
+// against the path and query of a URL ("/search?q=go"). A pattern
+// starting with "re:" is a regular expression, which matches if it
+// matches anywhere; anything else is a glob, which has to match the
+// whole path and query, and where "*" matches anything, including "/".
+//
+// A URL is in scope if it matches one of the Include patterns (or
+// there are none) and none of the Exclude patterns. The root URL is
+// always in scope.
+type Patterns struct {
+	Include []string
+	Exclude []string
+}
+
+const regexpPrefix = "re:"
+
+// Check makes sure every pattern can be compiled.
+func (p Patterns) Check() error {
+	_, err := p.compile()
+	return err
+}
+
+// matcher is a compiled set of Patterns.
+type matcher struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+func (p Patterns) compile() (*matcher, error) {
+	m := &matcher{}
+	var err error
+	if m.include, err = compilePatterns(p.Include); err != nil {
+		return nil, err
+	}
+	if m.exclude, err = compilePatterns(p.Exclude); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := []*regexp.Regexp{}
+	for _, p := range patterns {
+		expr := globToRegexp(p)
+		if strings.HasPrefix(p, regexpPrefix) {
+			expr = strings.TrimPrefix(p, regexpPrefix)
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("bad pattern %q: %s", p, err.Error())
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// globToRegexp turns a glob into an anchored regular expression.
+func globToRegexp(glob string) string {
+	parts := strings.Split(glob, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return "^" + strings.Join(parts, ".*") + "$"
+}
+
+// inScope checks a URL against the patterns. A nil matcher lets
+// everything through.
+func (m *matcher) inScope(u *url.URL) bool {
+	if m == nil {
+		return true
+	}
+	target := u.RequestURI()
+	if len(m.include) > 0 && !matchAny(m.include, target) {
+		return false
+	}
+	return !matchAny(m.exclude, target)
+}
+
+func matchAny(res []*regexp.Regexp, s string) bool {
+	for _, re := range res {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package crawler
+
+import (
+	"net/url"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("scope patterns", func() {
+	inScope := func(p Patterns, URL string) bool {
+		m, err := p.compile()
+		Expect(err).ToNot(HaveOccurred())
+		u, err := url.Parse(URL)
+		Expect(err).ToNot(HaveOccurred())
+		return m.inScope(u)
+	}
+	It("lets everything through with no patterns", func() {
+		Expect(inScope(Patterns{}, "http://example.com/anything?at=all")).To(BeTrue())
+	})
+	It("matches globs against the whole path and query", func() {
+		p := Patterns{Exclude: []string{"/tag/*", "/search?*"}}
+		Expect(inScope(p, "http://example.com/tag/go/page/2")).To(BeFalse())
+		Expect(inScope(p, "http://example.com/search?q=go")).To(BeFalse())
+		Expect(inScope(p, "http://example.com/search")).To(BeTrue())
+		Expect(inScope(p, "http://example.com/blog/tag/go")).To(BeTrue())
+	})
+	It("matches regular expressions anywhere", func() {
+		p := Patterns{Exclude: []string{"re:/admin(/|$)"}}
+		Expect(inScope(p, "http://example.com/admin")).To(BeFalse())
+		Expect(inScope(p, "http://example.com/site/admin/users")).To(BeFalse())
+		Expect(inScope(p, "http://example.com/administrivia")).To(BeTrue())
+	})
+	It("needs an include pattern to match, if there are any", func() {
+		p := Patterns{Include: []string{"/docs/*"}, Exclude: []string{"/docs/old/*"}}
+		Expect(inScope(p, "http://example.com/docs/intro")).To(BeTrue())
+		Expect(inScope(p, "http://example.com/docs/old/intro")).To(BeFalse())
+		Expect(inScope(p, "http://example.com/blog/")).To(BeFalse())
+	})
+	It("rejects bad regular expressions", func() {
+		Expect(Patterns{Include: []string{"re:("}}.Check()).To(MatchError(ContainSubstring(`bad pattern "re:("`)))
+	})
+})
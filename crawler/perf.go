@@ -0,0 +1,121 @@
+package crawler
+
+import (
+	"sort"
+	"time"
+)
+
+// DefaultTop is how many of the slowest and heaviest pages Perf lists,
+// if it isn't told.
+const DefaultTop = 10
+
+// Timing summarizes a set of durations: the median, the 95th
+// percentile, and the longest.
+type Timing struct {
+	P50 time.Duration
+	P95 time.Duration
+	Max time.Duration
+}
+
+// Perf is how quickly a crawl's pages came back, and how big they were.
+type Perf struct {
+	// Pages is the number of pages that were fetched and answered,
+	// successfully or not.
+	Pages int
+	// FirstByte is the time before each response started to arrive,
+	// and Fetch the time for the whole fetch.
+	FirstByte Timing
+	Fetch     Timing
+	// TotalBytes is the size of all the pages put together.
+	TotalBytes int64
+	// Slowest are the pages that took longest to fetch, and Heaviest
+	// the biggest, worst first.
+	Slowest  []PagePerf
+	Heaviest []PagePerf
+}
+
+// PagePerf is how long a page took to fetch, and how big it was.
+type PagePerf struct {
+	URL       string
+	Status    int
+	FirstByte time.Duration
+	Fetch     time.Duration
+	Size      int
+}
+
+// Perf measures the pages the crawl has fetched so far, and lists the
+// top slowest and heaviest of them. Assets aren't counted: they're only
+// checked, not fetched.
+func (state *State) Perf(top int) Perf {
+	if top <= 0 {
+		top = DefaultTop
+	}
+	state.Lock()
+	pages := []PagePerf{}
+	for URL, visit := range state.cache {
+		res := visit.Result
+		if visit.Asset != "" || res == nil || res.Status == 0 {
+			// No response to measure.
+			continue
+		}
+		pages = append(pages, PagePerf{
+			URL:       URL,
+			Status:    res.Status,
+			FirstByte: res.FirstByte,
+			Fetch:     res.Latency,
+			Size:      res.Size,
+		})
+	}
+	state.Unlock()
+
+	perf := Perf{Pages: len(pages), Slowest: []PagePerf{}, Heaviest: []PagePerf{}}
+	if len(pages) == 0 {
+		return perf
+	}
+	firstBytes, fetches := []time.Duration{}, []time.Duration{}
+	for _, p := range pages {
+		firstBytes = append(firstBytes, p.FirstByte)
+		fetches = append(fetches, p.Fetch)
+		perf.TotalBytes += int64(p.Size)
+	}
+	perf.FirstByte, perf.Fetch = timing(firstBytes), timing(fetches)
+
+	if top > len(pages) {
+		top = len(pages)
+	}
+	sort.Slice(pages, func(i, j int) bool {
+		if pages[i].Fetch != pages[j].Fetch {
+			return pages[i].Fetch > pages[j].Fetch
+		}
+		return pages[i].URL < pages[j].URL
+	})
+	perf.Slowest = append(perf.Slowest, pages[:top]...)
+	sort.Slice(pages, func(i, j int) bool {
+		if pages[i].Size != pages[j].Size {
+			return pages[i].Size > pages[j].Size
+		}
+		return pages[i].URL < pages[j].URL
+	})
+	perf.Heaviest = append(perf.Heaviest, pages[:top]...)
+	return perf
+}
+
+// timing summarizes a set of durations.
+func timing(durations []time.Duration) Timing {
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	return Timing{
+		P50: percentile(durations, 50),
+		P95: percentile(durations, 95),
+		Max: durations[len(durations)-1],
+	}
+}
+
+// percentile finds the pth percentile of a sorted set of durations:
+// the smallest one that at least p percent of them are no longer than.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
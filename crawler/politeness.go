@@ -0,0 +1,155 @@
+package crawler
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Politeness sets how hard a crawl may hit any one host. A zero
+// RequestsPerSecond or MinDelay means no limit of that kind.
+type Politeness struct {
+	// RequestsPerSecond and Burst define a token bucket: up to Burst
+	// requests may go out at once, refilled at RequestsPerSecond.
+	RequestsPerSecond float64
+	Burst             int
+	// MinDelay is the minimum time between two requests to the same host.
+	MinDelay time.Duration
+}
+
+// HostLimiter applies Politeness per host. Crawls that share a
+// HostLimiter share the limits for hosts they have in common, so two
+// crawls of the same site can't double the load on it: while they're
+// both running, the stricter setting of each kind wins. A crawl's
+// settings stop applying when it leaves.
+type HostLimiter struct {
+	sync.Mutex
+	hosts  map[string]*hostLimit
+	crawls int // the last id handed out by Join
+}
+
+// hostLimit is the token bucket for a single host. Its Politeness
+// is the strictest of the settings of the crawls using it.
+type hostLimit struct {
+	sync.Mutex
+	Politeness
+	crawls map[int]Politeness
+	tokens float64
+	filled time.Time
+	next   time.Time
+}
+
+// NewHostLimiter creates an empty HostLimiter.
+func NewHostLimiter() *HostLimiter {
+	return &HostLimiter{hosts: make(map[string]*hostLimit)}
+}
+
+// Join returns the id a crawl uses to wait on the limiter.
+func (l *HostLimiter) Join() int {
+	l.Lock()
+	defer l.Unlock()
+	l.crawls++
+	return l.crawls
+}
+
+// Wait blocks until crawl may make a request to host, under p and
+// the settings of the other crawls using the host.
+func (l *HostLimiter) Wait(crawl int, host string, p Politeness) {
+	delay := l.limit(crawl, host, p).reserve(time.Now())
+	if delay > 0 {
+		log.Debugf("politeness: waiting %v for %s", delay, host)
+		time.Sleep(delay)
+	}
+}
+
+// Leave drops a crawl's settings from every host it used, so that
+// they go back to the settings of the crawls still using them.
+func (l *HostLimiter) Leave(crawl int) {
+	l.Lock()
+	defer l.Unlock()
+	for _, h := range l.hosts {
+		h.set(crawl, nil)
+	}
+}
+
+// limit finds the bucket for host, creating it if need be, and sets
+// the crawl's settings for it.
+func (l *HostLimiter) limit(crawl int, host string, p Politeness) *hostLimit {
+	if p.RequestsPerSecond > 0 && p.Burst < 1 {
+		p.Burst = 1
+	}
+	l.Lock()
+	defer l.Unlock()
+	h, ok := l.hosts[host]
+	if !ok {
+		h = &hostLimit{crawls: make(map[int]Politeness), tokens: float64(p.Burst)}
+		l.hosts[host] = h
+	}
+	h.set(crawl, &p)
+	return h
+}
+
+// set changes a crawl's settings for the host, or drops them if p is
+// nil, and works out the strictest setting of each kind again.
+func (h *hostLimit) set(crawl int, p *Politeness) {
+	h.Lock()
+	defer h.Unlock()
+	if p != nil {
+		h.crawls[crawl] = *p
+	} else if _, ok := h.crawls[crawl]; ok {
+		delete(h.crawls, crawl)
+	} else {
+		return
+	}
+
+	strictest := Politeness{}
+	for _, p := range h.crawls {
+		if p.RequestsPerSecond > 0 {
+			if strictest.RequestsPerSecond == 0 || p.RequestsPerSecond < strictest.RequestsPerSecond {
+				strictest.RequestsPerSecond = p.RequestsPerSecond
+			}
+			if strictest.Burst == 0 || p.Burst < strictest.Burst {
+				strictest.Burst = p.Burst
+			}
+		}
+		if p.MinDelay > strictest.MinDelay {
+			strictest.MinDelay = p.MinDelay
+		}
+	}
+	h.Politeness = strictest
+	if h.RequestsPerSecond > 0 && h.tokens > float64(h.Burst) {
+		h.tokens = float64(h.Burst)
+	}
+}
+
+// reserve books the next request slot for the host and returns how long
+// the caller must wait, as of now, to use it.
+func (h *hostLimit) reserve(now time.Time) time.Duration {
+	h.Lock()
+	defer h.Unlock()
+
+	start := now
+	if h.RequestsPerSecond > 0 {
+		// Refill the bucket for the time that has passed.
+		if !h.filled.IsZero() {
+			h.tokens += now.Sub(h.filled).Seconds() * h.RequestsPerSecond
+			if h.tokens > float64(h.Burst) {
+				h.tokens = float64(h.Burst)
+			}
+		}
+		h.filled = now
+		// Out of tokens: wait until one would be refilled. Tokens go
+		// negative so that waiters queue up behind each other.
+		if h.tokens < 1 {
+			wait := (1 - h.tokens) / h.RequestsPerSecond
+			start = now.Add(time.Duration(wait * float64(time.Second)))
+		}
+		h.tokens--
+	}
+	if h.MinDelay > 0 && start.Before(h.next) {
+		start = h.next
+	}
+	h.next = start.Add(h.MinDelay)
+	return start.Sub(now)
+}
@@ -0,0 +1,97 @@
+package crawler
+
+import (
+	"time"
+
+	"github.com/joemcmahon/joe_macmahon_technical_test/crawler/test/mock_fetcher"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("politeness", func() {
+	var now time.Time
+	BeforeEach(func() {
+		now = time.Now()
+	})
+	Context("with no limits", func() {
+		It("never waits", func() {
+			h := NewHostLimiter().limit(1, "example.com", Politeness{})
+			for i := 0; i < 10; i++ {
+				Expect(h.reserve(now)).To(BeZero())
+			}
+		})
+	})
+	Context("with a token bucket", func() {
+		It("allows a burst, then spaces requests out", func() {
+			h := NewHostLimiter().limit(1, "example.com", Politeness{RequestsPerSecond: 2, Burst: 2})
+			Expect(h.reserve(now)).To(BeZero())
+			Expect(h.reserve(now)).To(BeZero())
+			Expect(h.reserve(now)).To(Equal(500 * time.Millisecond))
+			Expect(h.reserve(now)).To(Equal(time.Second))
+		})
+		It("refills over time", func() {
+			h := NewHostLimiter().limit(1, "example.com", Politeness{RequestsPerSecond: 1, Burst: 1})
+			Expect(h.reserve(now)).To(BeZero())
+			Expect(h.reserve(now.Add(time.Second))).To(BeZero())
+		})
+	})
+	Context("with a minimum delay", func() {
+		It("spaces every request out", func() {
+			h := NewHostLimiter().limit(1, "example.com", Politeness{MinDelay: 100 * time.Millisecond})
+			Expect(h.reserve(now)).To(BeZero())
+			Expect(h.reserve(now)).To(Equal(100 * time.Millisecond))
+			Expect(h.reserve(now.Add(time.Second))).To(BeZero())
+		})
+	})
+	Context("shared between crawls", func() {
+		It("uses the strictest limits of the crawls of the same host", func() {
+			l := NewHostLimiter()
+			a := l.limit(l.Join(), "example.com", Politeness{RequestsPerSecond: 10, Burst: 5})
+			b := l.limit(l.Join(), "example.com", Politeness{RequestsPerSecond: 1, Burst: 1, MinDelay: time.Second})
+			Expect(a).To(BeIdenticalTo(b))
+			Expect(a.RequestsPerSecond).To(Equal(1.0))
+			Expect(a.Burst).To(Equal(1))
+			Expect(a.MinDelay).To(Equal(time.Second))
+		})
+		It("drops a crawl's limits when it leaves", func() {
+			l := NewHostLimiter()
+			fast, slow := l.Join(), l.Join()
+			l.limit(fast, "example.com", Politeness{RequestsPerSecond: 10, Burst: 5})
+			h := l.limit(slow, "example.com", Politeness{RequestsPerSecond: 1, Burst: 1, MinDelay: time.Second})
+			l.Leave(slow)
+			Expect(h.Politeness).To(Equal(Politeness{RequestsPerSecond: 10, Burst: 5}))
+			l.Leave(fast)
+			Expect(h.Politeness).To(Equal(Politeness{}))
+			Expect(h.reserve(now)).To(BeZero())
+			Expect(h.reserve(now)).To(BeZero())
+		})
+		It("lets a crawl with no limits run unlimited on its own", func() {
+			l := NewHostLimiter()
+			slow := l.Join()
+			l.limit(slow, "example.com", Politeness{RequestsPerSecond: 1, Burst: 1})
+			l.Leave(slow)
+			h := l.limit(l.Join(), "example.com", Politeness{})
+			for i := 0; i < 10; i++ {
+				Expect(h.reserve(now)).To(BeZero())
+			}
+		})
+		It("keeps other hosts separate", func() {
+			l := NewHostLimiter()
+			a := l.limit(l.Join(), "example.com", Politeness{MinDelay: time.Second})
+			b := l.limit(l.Join(), "golang.org", Politeness{})
+			Expect(a).ToNot(BeIdenticalTo(b))
+			Expect(b.MinDelay).To(BeZero())
+		})
+	})
+	Context("used by a crawl", func() {
+		It("stops applying the crawl's limits when it finishes", func() {
+			l := NewHostLimiter()
+			state := New(knownURL, MockFetcher.New(), Options{Limiter: l, Politeness: Politeness{MinDelay: time.Millisecond}})
+			state.Start()
+			state.Wait()
+			Expect(l.hosts).To(HaveKey("golang.org"))
+			Expect(l.hosts["golang.org"].Politeness).To(BeZero())
+		})
+	})
+})
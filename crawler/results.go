@@ -0,0 +1,427 @@
+package crawler
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/joemcmahon/joe_macmahon_technical_test/crawler/page"
+	sharedTree "github.com/joemcmahon/joe_macmahon_technical_test/crawler/shared-tree"
+)
+
+// Outcome is what happened when we tried to crawl a URL.
+type Outcome int
+
+const (
+	// Pending URLs have been found, but not fetched yet.
+	Pending Outcome = iota
+	// Fetching URLs are being fetched right now.
+	Fetching
+	// Fetched URLs were fetched successfully.
+	Fetched
+	// Failed URLs couldn't be fetched.
+	Failed
+	// Invalid URLs couldn't be parsed.
+	Invalid
+	// Offsite URLs are on another site, and aren't fetched.
+	Offsite
+	// Blocked URLs are ones robots.txt won't let us fetch.
+	Blocked
+	// Unfetched URLs were left alone because the crawl hit a limit.
+	Unfetched
+	// OutOfScope URLs are excluded by the crawl's patterns.
+	OutOfScope
+	// Redirected URLs redirect off the site; the redirect isn't followed.
+	Redirected
+	// Duplicate URLs are the same page as another URL; their links
+	// aren't followed.
+	Duplicate
+)
+
+var outcomeNames = map[Outcome]string{
+	Pending:    "pending",
+	Fetching:   "fetching",
+	Fetched:    "fetched",
+	Failed:     "failed",
+	Invalid:    "invalid",
+	Offsite:    "offsite",
+	Blocked:    "blocked by robots",
+	Unfetched:  "unfetched",
+	OutOfScope: "out of scope",
+	Redirected: "redirected offsite",
+	Duplicate:  "duplicate",
+}
+
+func (o Outcome) String() string {
+	return outcomeNames[o]
+}
+
+// Visit is what happened when we tried to crawl a URL.
+type Visit struct {
+	Outcome Outcome
+	// Result is what the Fetcher found out; nil if we didn't fetch
+	// the URL. It doesn't keep the page's body or links.
+	Result *page.Result `json:",omitempty"`
+	// Err says why the URL failed or was invalid.
+	Err *page.Error `json:",omitempty"`
+	// Asset is the kind of asset the URL is; empty for a page.
+	Asset page.Asset `json:",omitempty"`
+	// DuplicateOf is the page a Duplicate is the same as, and
+	// DuplicateReason how we know.
+	DuplicateOf     string          `json:",omitempty"`
+	DuplicateReason DuplicateReason `json:",omitempty"`
+}
+
+// DuplicateReason says how we know a page is a duplicate of another.
+type DuplicateReason string
+
+// The ways we spot duplicates.
+const (
+	// SameCanonical pages name another URL as their canonical one.
+	SameCanonical DuplicateReason = "canonical"
+	// SameContent pages have the same text as another.
+	SameContent DuplicateReason = "same content"
+)
+
+// Result is one URL in the crawl tree, with what happened to it. Each
+// URL is in the tree once, under the page it was first found on.
+type Result struct {
+	URL string
+	// Parent is the URL of the page this one was found on;
+	// empty for the root.
+	Parent string
+	// Depth is the number of links between the root and this URL.
+	Depth   int
+	Outcome Outcome
+	// Status is the HTTP status of the fetch; zero if unknown.
+	Status int
+	// FinalURL is where the fetch ended up, after any redirects.
+	FinalURL    string
+	ContentType string
+	// Size is the size of the response body, in bytes.
+	Size int
+	// Latency is how long the whole fetch took, and FirstByte how
+	// long before the response started to arrive.
+	Latency   time.Duration
+	FirstByte time.Duration
+	// Error says why the URL failed or was invalid, and ErrorKind
+	// what sort of failure it was.
+	Error     string
+	ErrorKind page.ErrorKind
+	// Asset is the kind of asset the URL is -- an image, a script,
+	// and so on -- or empty for a page. Assets are never parsed, so
+	// they have no children.
+	Asset page.Asset
+	// Canonical is the URL the page says is its canonical one, and
+	// Hash a fingerprint of its text.
+	Canonical string
+	Hash      string
+	// DuplicateOf is the page a Duplicate is the same as, and
+	// DuplicateReason how we know.
+	DuplicateOf     string
+	DuplicateReason DuplicateReason
+	// Redirects are the redirects the fetch went through, in order.
+	Redirects []page.Redirect
+	// Anchors are the fragments links can point at on the page.
+	Anchors []string
+	// Meta is what the page says about itself; nil if it wasn't
+	// fetched, or isn't a page.
+	Meta *page.Meta
+	// Sitemap is set if the URL came from the sitemap rather than a link.
+	Sitemap bool
+	// Orphaned is set if the URL is in the sitemap, but no page we
+	// crawled links to it.
+	Orphaned bool
+	// LinkedFrom is every link to this URL we found: the page it's
+	// on, and its anchor text.
+	LinkedFrom []page.Link
+	Children   []*Result
+}
+
+// Results returns the crawl tree as it stands, or nil if nothing
+// has been crawled yet.
+func (state *State) Results() *Result {
+	if state == nil || state.tree == nil {
+		return nil
+	}
+	root := state.tree.Copy()
+	if root == nil {
+		return nil
+	}
+	state.Lock()
+	defer state.Unlock()
+	return state.result(root, "", 0)
+}
+
+// result builds the Result for a node in a copy of the tree, and
+// everything under it. Must be called holding the lock.
+func (state *State) result(n *sharedTree.Node, parent string, depth int) *Result {
+	r := &Result{
+		URL:      n.URL,
+		Parent:   parent,
+		Depth:    depth,
+		Sitemap:  n.Sitemap,
+		Orphaned: n.Sitemap && !state.linked[n.URL],
+	}
+	r.Outcome = state.outcome(n.URL)
+	if visit, ok := state.cache[n.URL]; ok {
+		if res := visit.Result; res != nil {
+			r.Status = res.Status
+			r.FinalURL = res.FinalURL
+			r.ContentType = res.ContentType
+			r.Size = res.Size
+			r.Latency = res.Latency
+			r.FirstByte = res.FirstByte
+			r.Anchors = res.Anchors
+			r.Redirects = res.Redirects
+			r.Canonical = res.Canonical
+			r.Hash = res.Hash
+			r.Meta = res.Meta
+		}
+		r.DuplicateOf, r.DuplicateReason = visit.DuplicateOf, visit.DuplicateReason
+		r.Asset = visit.Asset
+		if visit.Err != nil {
+			r.Error = visit.Err.Error()
+			r.ErrorKind = visit.Err.Kind
+		}
+	}
+	for _, link := range n.LinkedFrom {
+		r.LinkedFrom = append(r.LinkedFrom, page.Link{URL: link.Source, Text: link.Text})
+	}
+	for _, child := range n.Children {
+		r.Children = append(r.Children, state.result(child, n.URL, depth+1))
+	}
+	return r
+}
+
+// outcome works out what happened to URL from the cache. Must be
+// called holding the lock.
+func (state *State) outcome(URL string) Outcome {
+	visit, ok := state.cache[URL]
+	switch {
+	case state.frontier[URL]:
+		return Unfetched
+	case !ok:
+		return Pending
+	}
+	return visit.Outcome
+}
+
+// BrokenLink is a URL the crawl couldn't fetch, with every link to it.
+type BrokenLink struct {
+	URL string
+	// Outcome is Failed or Invalid.
+	Outcome   Outcome
+	Status    int
+	Error     string
+	ErrorKind page.ErrorKind
+	// LinkedFrom is every link to the URL: the page it's on, and its
+	// anchor text. Empty if the root itself is broken.
+	LinkedFrom []page.Link
+}
+
+// Broken returns every URL that failed or was invalid, with the links
+// to it, sorted by URL.
+func (state *State) Broken() []BrokenLink {
+	broken := []BrokenLink{}
+	var walk func(*Result)
+	walk = func(r *Result) {
+		if r.Outcome == Failed || r.Outcome == Invalid {
+			broken = append(broken, BrokenLink{
+				URL:        r.URL,
+				Outcome:    r.Outcome,
+				Status:     r.Status,
+				Error:      r.Error,
+				ErrorKind:  r.ErrorKind,
+				LinkedFrom: r.LinkedFrom,
+			})
+		}
+		for _, child := range r.Children {
+			walk(child)
+		}
+	}
+	if root := state.Results(); root != nil {
+		walk(root)
+	}
+	sort.Slice(broken, func(i, j int) bool { return broken[i].URL < broken[j].URL })
+	return broken
+}
+
+// RedirectChain is a URL that went through more than one redirect, or
+// whose redirects never got anywhere.
+type RedirectChain struct {
+	URL  string
+	Hops []page.Redirect
+	// FinalURL is where the chain ended up.
+	FinalURL  string
+	Outcome   Outcome
+	Error     string
+	ErrorKind page.ErrorKind
+	// LinkedFrom is every link to the URL, which could go straight
+	// to where it ends up instead.
+	LinkedFrom []page.Link
+}
+
+// RedirectChains returns every URL whose redirects took more than one
+// hop, or looped or went on too long, sorted by URL.
+func (state *State) RedirectChains() []RedirectChain {
+	chains := []RedirectChain{}
+	var walk func(*Result)
+	walk = func(r *Result) {
+		failed := r.Error != "" && (r.ErrorKind == page.RedirectLoop || r.ErrorKind == page.TooManyRedirects)
+		if len(r.Redirects) > 1 || failed {
+			chains = append(chains, RedirectChain{
+				URL:        r.URL,
+				Hops:       r.Redirects,
+				FinalURL:   r.FinalURL,
+				Outcome:    r.Outcome,
+				Error:      r.Error,
+				ErrorKind:  r.ErrorKind,
+				LinkedFrom: r.LinkedFrom,
+			})
+		}
+		for _, child := range r.Children {
+			walk(child)
+		}
+	}
+	if root := state.Results(); root != nil {
+		walk(root)
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i].URL < chains[j].URL })
+	return chains
+}
+
+// DuplicateGroup is a page, and the other URLs that are the same page.
+type DuplicateGroup struct {
+	URL        string
+	Duplicates []DuplicatePage
+}
+
+// DuplicatePage is a URL that duplicates another, and how we know.
+type DuplicatePage struct {
+	URL    string
+	Reason DuplicateReason
+}
+
+// Duplicates groups every duplicate page under the page it duplicates,
+// sorted by URL.
+func (state *State) Duplicates() []DuplicateGroup {
+	state.Lock()
+	defer state.Unlock()
+	found := map[string]*DuplicateGroup{}
+	for URL, visit := range state.cache {
+		if visit.Outcome != Duplicate {
+			continue
+		}
+		if found[visit.DuplicateOf] == nil {
+			found[visit.DuplicateOf] = &DuplicateGroup{URL: visit.DuplicateOf}
+		}
+		group := found[visit.DuplicateOf]
+		group.Duplicates = append(group.Duplicates, DuplicatePage{URL: URL, Reason: visit.DuplicateReason})
+	}
+
+	groups := []DuplicateGroup{}
+	for _, group := range found {
+		sort.Slice(group.Duplicates, func(i, j int) bool { return group.Duplicates[i].URL < group.Duplicates[j].URL })
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].URL < groups[j].URL })
+	return groups
+}
+
+// AnchorLink is a link to a fragment of a page: the page it's on, the
+// page it goes to (without the fragment), the fragment, and its text.
+type AnchorLink struct {
+	Source   string
+	Target   string
+	Fragment string
+	Text     string
+}
+
+// BrokenAnchor is a fragment that isn't on the page it's linked to,
+// with every link to it.
+type BrokenAnchor struct {
+	URL        string
+	Fragment   string
+	LinkedFrom []page.Link
+}
+
+// BrokenAnchors returns every fragment that links point at but that
+// isn't an id or <a name> on its page, sorted by URL and fragment.
+// Links to pages that weren't fetched, or that aren't HTML, can't be
+// checked, and are left out.
+func (state *State) BrokenAnchors() []BrokenAnchor {
+	state.Lock()
+	defer state.Unlock()
+	found := map[string]*BrokenAnchor{}
+	for link := range state.anchorLinks {
+		visit := state.cache[link.Target]
+		if visit.Outcome != Fetched || visit.Result == nil || !strings.Contains(visit.Result.ContentType, "html") {
+			continue
+		}
+		if hasAnchor(visit.Result.Anchors, link.Fragment) {
+			continue
+		}
+		key := link.Target + "#" + link.Fragment
+		if found[key] == nil {
+			found[key] = &BrokenAnchor{URL: link.Target, Fragment: link.Fragment}
+		}
+		found[key].LinkedFrom = append(found[key].LinkedFrom, page.Link{URL: link.Source, Text: link.Text})
+	}
+
+	broken := []BrokenAnchor{}
+	for _, b := range found {
+		sort.Slice(b.LinkedFrom, func(i, j int) bool { return b.LinkedFrom[i].URL < b.LinkedFrom[j].URL })
+		broken = append(broken, *b)
+	}
+	sort.Slice(broken, func(i, j int) bool {
+		if broken[i].URL != broken[j].URL {
+			return broken[i].URL < broken[j].URL
+		}
+		return broken[i].Fragment < broken[j].Fragment
+	})
+	return broken
+}
+
+// hasAnchor checks whether fragment is one of a page's anchors. Every
+// page has a "top", even if it doesn't say so.
+func hasAnchor(anchors []string, fragment string) bool {
+	if strings.EqualFold(fragment, "top") {
+		return true
+	}
+	for _, anchor := range anchors {
+		if anchor == fragment {
+			return true
+		}
+	}
+	return false
+}
+
+// Stats is a summary of how far a crawl has got.
+type Stats struct {
+	// Started is when the crawl started; zero if it hasn't.
+	Started time.Time
+	// Fetched is the number of pages fetched.
+	Fetched int
+	// Queued is the number of URLs waiting to be crawled.
+	Queued int
+	// Errors is the number of URLs that failed or were invalid.
+	Errors int
+}
+
+// Stats summarizes the crawl as it stands.
+func (state *State) Stats() Stats {
+	state.Lock()
+	defer state.Unlock()
+	stats := Stats{
+		Started: state.started,
+		Fetched: state.fetched,
+		Queued:  int(state.unprocessed.Len()),
+	}
+	for _, visit := range state.cache {
+		if visit.Outcome == Failed || visit.Outcome == Invalid {
+			stats.Errors++
+		}
+	}
+	return stats
+}
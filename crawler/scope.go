@@ -0,0 +1,105 @@
+package crawler
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// HostPolicy says which hosts count as part of the site being crawled.
+type HostPolicy int
+
+const (
+	// ExactHost only crawls the root URL's host.
+	ExactHost HostPolicy = iota
+	// Subdomains crawls the root URL's host and every host under it:
+	// for example.com, www.example.com and docs.example.com too.
+	Subdomains
+	// RegistrableDomain crawls every host under the same registrable
+	// domain as the root URL's host: for www.example.co.uk, all of
+	// example.co.uk, but not other .co.uk sites.
+	RegistrableDomain
+)
+
+var hostPolicyNames = map[HostPolicy]string{
+	ExactHost:         "exact host",
+	Subdomains:        "subdomains",
+	RegistrableDomain: "registrable domain",
+}
+
+func (p HostPolicy) String() string {
+	return hostPolicyNames[p]
+}
+
+// Scope says which URLs are on the site being crawled; the rest are
+// offsite, and aren't fetched. The zero Scope is the root URL's host
+// and scheme only.
+type Scope struct {
+	Hosts HostPolicy
+	// Aliases are other hosts that are the same site, whatever
+	// the policy.
+	Aliases []string
+	// AnyScheme treats http and https as the same site.
+	AnyScheme bool
+}
+
+// site is a Scope applied to a particular root URL.
+type site struct {
+	scope   Scope
+	scheme  string
+	host    string // including any port
+	name    string // the host name, without the port
+	domain  string // the registrable domain, for RegistrableDomain
+	aliases map[string]bool
+}
+
+func newSite(scope Scope, root *url.URL) *site {
+	s := &site{
+		scope:   scope,
+		scheme:  root.Scheme,
+		host:    root.Host,
+		name:    strings.ToLower(root.Hostname()),
+		aliases: make(map[string]bool),
+	}
+	for _, alias := range scope.Aliases {
+		s.aliases[strings.ToLower(alias)] = true
+	}
+	if scope.Hosts == RegistrableDomain {
+		// IP addresses and hosts like localhost have no registrable
+		// domain; they're just themselves.
+		s.domain = s.name
+		if domain, err := publicsuffix.EffectiveTLDPlusOne(s.name); err == nil {
+			s.domain = domain
+		}
+	}
+	return s
+}
+
+// contains checks whether u is on the site.
+func (s *site) contains(u *url.URL) bool {
+	if s == nil || !s.sameScheme(u.Scheme) {
+		return false
+	}
+	if u.Host == s.host || s.aliases[strings.ToLower(u.Host)] || s.aliases[strings.ToLower(u.Hostname())] {
+		return true
+	}
+	name := strings.ToLower(u.Hostname())
+	switch s.scope.Hosts {
+	case Subdomains:
+		return name == s.name || strings.HasSuffix(name, "."+s.name)
+	case RegistrableDomain:
+		return name == s.domain || strings.HasSuffix(name, "."+s.domain)
+	}
+	return false
+}
+
+// sameScheme checks a URL's scheme against the root's. A URL with no
+// scheme is relative to the page it's on, so it's the same.
+func (s *site) sameScheme(scheme string) bool {
+	if scheme == "" || scheme == s.scheme {
+		return true
+	}
+	web := map[string]bool{"http": true, "https": true}
+	return s.scope.AnyScheme && web[scheme] && web[s.scheme]
+}
@@ -0,0 +1,62 @@
+package crawler
+
+import (
+	"net/url"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("scope", func() {
+	onSite := func(scope Scope, root, URL string) bool {
+		r, err := url.Parse(root)
+		Expect(err).ToNot(HaveOccurred())
+		u, err := url.Parse(URL)
+		Expect(err).ToNot(HaveOccurred())
+		return newSite(scope, r).contains(u)
+	}
+	Context("exact host", func() {
+		It("only takes in the root's host and scheme", func() {
+			Expect(onSite(Scope{}, "http://example.com/", "http://example.com/a")).To(BeTrue())
+			Expect(onSite(Scope{}, "http://example.com/", "http://www.example.com/a")).To(BeFalse())
+			Expect(onSite(Scope{}, "http://example.com/", "https://example.com/a")).To(BeFalse())
+		})
+		It("takes in aliases", func() {
+			scope := Scope{Aliases: []string{"www.example.com", "example.net"}}
+			Expect(onSite(scope, "http://example.com/", "http://WWW.example.com/a")).To(BeTrue())
+			Expect(onSite(scope, "http://example.com/", "http://example.net/a")).To(BeTrue())
+			Expect(onSite(scope, "http://example.com/", "http://docs.example.com/a")).To(BeFalse())
+		})
+	})
+	Context("subdomains", func() {
+		scope := Scope{Hosts: Subdomains}
+		It("takes in hosts under the root's", func() {
+			Expect(onSite(scope, "http://example.com/", "http://docs.example.com/a")).To(BeTrue())
+			Expect(onSite(scope, "http://example.com/", "http://a.b.example.com/a")).To(BeTrue())
+			Expect(onSite(scope, "http://example.com/", "http://notexample.com/a")).To(BeFalse())
+			Expect(onSite(scope, "http://www.example.com/", "http://example.com/a")).To(BeFalse())
+		})
+	})
+	Context("registrable domain", func() {
+		scope := Scope{Hosts: RegistrableDomain}
+		It("takes in the whole domain", func() {
+			Expect(onSite(scope, "http://www.example.com/", "http://example.com/a")).To(BeTrue())
+			Expect(onSite(scope, "http://www.example.com/", "http://docs.example.com/a")).To(BeTrue())
+		})
+		It("knows about public suffixes", func() {
+			Expect(onSite(scope, "http://www.example.co.uk/", "http://shop.example.co.uk/a")).To(BeTrue())
+			Expect(onSite(scope, "http://www.example.co.uk/", "http://other.co.uk/a")).To(BeFalse())
+		})
+		It("copes with hosts that have no registrable domain", func() {
+			Expect(onSite(scope, "http://localhost:8080/", "http://localhost:8080/a")).To(BeTrue())
+			Expect(onSite(scope, "http://127.0.0.1/", "http://127.0.0.2/a")).To(BeFalse())
+		})
+	})
+	Context("any scheme", func() {
+		It("treats http and https as the same site", func() {
+			scope := Scope{AnyScheme: true}
+			Expect(onSite(scope, "http://example.com/", "https://example.com/a")).To(BeTrue())
+			Expect(onSite(scope, "http://example.com/", "ftp://example.com/a")).To(BeFalse())
+		})
+	})
+})
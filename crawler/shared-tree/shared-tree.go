@@ -1,49 +1,125 @@
 package sharedTree
 
 import (
-	"github.com/disiqueira/gotree"
+	"sort"
+
 	log "github.com/sirupsen/logrus"
 )
 
-// addition is an individual tree item to be added at a specific point.
-// InsertPoint is nil if we are building a new tree. The Response is
-// indeed a pointer into the shared tree somewhere; the caller must
-// treat this as an opaque token and not try to use it directly.
+// The shared tree records the crawl's link graph: every URL seen, and
+// every link between them. The site tree is derived from the graph as
+// a spanning tree: each URL goes in the tree under the page it was
+// first found on, so it appears in the tree exactly once.
+
+// Link is a link to a URL from another page, with the link's anchor text.
+type Link struct {
+	Source string
+	Text   string
+}
+
+// Node is a single URL in a copy of the tree, as returned by Copy.
+type Node struct {
+	URL string
+	// Sitemap is set if the URL came from the site's sitemap
+	// rather than from a link.
+	Sitemap bool
+	// LinkedFrom is every link to this URL that we've found,
+	// not just the one that put it in the tree.
+	LinkedFrom []Link
+	Children   []*Node
+}
+
+// node is a URL in the graph itself. It belongs to the tree process.
+type node struct {
+	URL        string
+	sitemap    bool
+	linkedFrom []Link
+	// The URLs first found on this page, in the order we found them.
+	children []string
+}
+
+// addition is a sighting of target to be recorded. Source is empty
+// if target is the root. Response is sent true if target is new.
 type addition struct {
-	item        string
-	insertPoint *gotree.Tree
-	response    chan *gotree.Tree
+	source   string
+	target   string
+	text     string
+	sitemap  bool
+	response chan bool
+}
+
+// copyReq is sent to request a copy of the tree. The copy
+// is written back to the Response channel.
+type copyReq struct {
+	response chan *Node
+}
+
+// saveReq is sent to request the whole graph, to be saved.
+type saveReq struct {
+	response chan Saved
 }
 
-// FormatReq is sent to request a formatted dump of the tree. The
-// printed tree is written back to the Response channel.
-type formatReq struct {
-	response chan string
+// Saved is the whole graph, in a form that can be saved
+// and loaded again with Load.
+type Saved struct {
+	Root  string
+	Nodes []SavedNode
+}
+
+// SavedNode is a URL in a Saved graph. Children are the URLs
+// under it in the tree.
+type SavedNode struct {
+	URL        string
+	Sitemap    bool
+	LinkedFrom []Link
+	Children   []string
 }
 
 // Tree represents the tree management process itself. Send
-// items to Tree.Add; send formatting requests to Format; send true
-// to Tree.Quit to stop the process.
+// items to Tree.Add; send copy requests to Copy; send save
+// requests to Save; send true to Tree.Quit to stop the process.
+// Once the process has stopped, done is closed, and requests
+// get empty answers rather than waiting forever.
 type Tree struct {
-	tree   *gotree.Tree
-	add    chan addition
-	format chan formatReq
-	quit   chan bool
+	root  string
+	nodes map[string]*node
+	add   chan addition
+	copy  chan copyReq
+	save  chan saveReq
+	quit  chan bool
+	done  chan struct{}
 }
 
 // New creates a new tree.
 func New() *Tree {
 	// Create and return the tree.
 	t := Tree{
-		tree:   nil,
-		add:    make(chan addition, 1),
-		format: make(chan formatReq, 1),
-		quit:   make(chan bool, 1),
+		nodes: make(map[string]*node),
+		add:   make(chan addition, 1),
+		copy:  make(chan copyReq, 1),
+		save:  make(chan saveReq, 1),
+		quit:  make(chan bool, 1),
+		done:  make(chan struct{}),
 	}
 
 	return &t
 }
 
+// Load creates a new tree holding a graph saved by Save.
+func Load(saved Saved) *Tree {
+	t := New()
+	t.root = saved.Root
+	for _, n := range saved.Nodes {
+		t.nodes[n.URL] = &node{
+			URL:        n.URL,
+			sitemap:    n.Sitemap,
+			linkedFrom: n.LinkedFrom,
+			children:   n.Children,
+		}
+	}
+	return t
+}
+
 // Run runs the tree. We wait for work on our work queue, execute
 // it, and wait for more work
 func (t *Tree) Run() {
@@ -51,58 +127,161 @@ func (t *Tree) Run() {
 		for {
 			select {
 			case add := <-t.add:
-				log.Debugf("adding item to tree")
-				var newT gotree.Tree
-				// Build a new tree if it is empty.
-				if t.tree == nil {
-					log.Debugf("building new tree")
-					newT = gotree.New(add.item)
-					t.tree = &newT
-					// Insert at the insertion point if it is not.
-				} else {
-					log.Debugf("adding to existing tree")
-					newT = (*add.insertPoint).Add(add.item)
-				}
-				log.Debug((*t.tree).Print())
-				// Send back the new insert point.
-				log.Debugf("sending back the insert point")
-				add.response <- &newT
-
-			case req := <-t.format:
-				log.Debugf("formatting tree")
-				req.response <- (*t.tree).Print()
+				log.Debugf("adding %s to graph", add.target)
+				add.response <- t.record(add)
+
+			case req := <-t.copy:
+				log.Debugf("copying tree")
+				req.response <- t.clone(t.root)
+
+			case req := <-t.save:
+				log.Debugf("saving graph")
+				req.response <- t.saved()
 
 			case <-t.quit:
 				log.Debugf("tree exits")
+				close(t.done)
 				return
 			}
 		}
 	}()
 }
 
-// AddAt inserts a new item at the specified insert point and
-// returns the new item as an insert point. This allows us to
-// build trees downward from the root.
-func (t *Tree) AddAt(point *gotree.Tree, s string) *gotree.Tree {
-	a := addition{
-		item:        s,
-		insertPoint: point,
-		response:    make(chan *gotree.Tree, 1),
+// record adds a sighting to the graph, and reports whether the
+// target is new. Only called by the tree process.
+func (t *Tree) record(add addition) bool {
+	target, seen := t.nodes[add.target]
+	if !seen {
+		target = &node{URL: add.target, sitemap: add.sitemap}
+		t.nodes[add.target] = target
+		// The first sighting puts the URL in the tree.
+		if add.source == "" {
+			log.Debugf("building new tree")
+			t.root = add.target
+		} else if source, ok := t.nodes[add.source]; ok {
+			source.children = append(source.children, add.target)
+		}
+	}
+	if add.source == "" || add.sitemap {
+		// Not a link, so no edge.
+		return !seen
+	}
+	link := Link{Source: add.source, Text: add.text}
+	for _, l := range target.linkedFrom {
+		if l == link {
+			return !seen
+		}
+	}
+	target.linkedFrom = append(target.linkedFrom, link)
+	return !seen
+}
+
+// send hands an addition to the tree process and waits for the answer.
+func (t *Tree) send(a addition) bool {
+	a.response = make(chan bool, 1)
+	select {
+	case t.add <- a:
+	case <-t.done:
+		return false
+	}
+	select {
+	case added := <-a.response:
+		return added
+	case <-t.done:
+		return false
+	}
+}
+
+// AddRoot records the root of the tree.
+func (t *Tree) AddRoot(URL string) {
+	t.send(addition{target: URL})
+}
+
+// AddLink records a link from source to target, with its anchor text.
+// If target hasn't been seen before, it goes in the tree under source;
+// AddLink returns true if so.
+func (t *Tree) AddLink(source, target, text string) bool {
+	return t.send(addition{source: source, target: target, text: text})
+}
+
+// AddSitemap records that target is listed in the sitemap of the site
+// root is on. If target hasn't been seen before, it goes in the tree
+// under root; AddSitemap returns true if so.
+func (t *Tree) AddSitemap(root, target string) bool {
+	return t.send(addition{source: root, target: target, sitemap: true})
+}
+
+// Copy returns a copy of the tree as it stands, or nil if it is
+// empty. The copy is made by the tree process so that it can't race
+// with additions.
+func (t *Tree) Copy() *Node {
+	req := copyReq{response: make(chan *Node, 1)}
+	select {
+	case t.copy <- req:
+	case <-t.done:
+		return nil
+	}
+	select {
+	case n := <-req.response:
+		return n
+	case <-t.done:
+		return nil
 	}
-	log.Debugf("adding %s", a.item)
-	t.add <- a
-	log.Debugf("wait for response")
-	p := <-a.response
-	log.Debugf("completed add of %s", a.item)
-	return p
 }
 
-// Format returns a formatted version of the tree, using gotree's Print().
-func (t *Tree) Format() string {
-	return (*t.tree).Print()
+// Save returns the whole graph, to be saved and loaded again later.
+func (t *Tree) Save() Saved {
+	req := saveReq{response: make(chan Saved, 1)}
+	select {
+	case t.save <- req:
+	case <-t.done:
+		return Saved{}
+	}
+	select {
+	case s := <-req.response:
+		return s
+	case <-t.done:
+		return Saved{}
+	}
 }
 
-// Quit stops the process.
+// Quit stops the process. Quitting a tree that has already
+// stopped does nothing.
 func (t *Tree) Quit() {
-	t.quit <- true
+	select {
+	case t.quit <- true:
+	case <-t.done:
+	default:
+		// Already asked to quit.
+	}
+}
+
+// clone makes a copy of the tree under URL. Only called by the tree
+// process.
+func (t *Tree) clone(URL string) *Node {
+	n, ok := t.nodes[URL]
+	if !ok {
+		return nil
+	}
+	c := &Node{URL: n.URL, Sitemap: n.sitemap}
+	c.LinkedFrom = append(c.LinkedFrom, n.linkedFrom...)
+	for _, child := range n.children {
+		c.Children = append(c.Children, t.clone(child))
+	}
+	return c
+}
+
+// saved copies the graph for Save. Only called by the tree process.
+func (t *Tree) saved() Saved {
+	s := Saved{Root: t.root, Nodes: []SavedNode{}}
+	for _, n := range t.nodes {
+		s.Nodes = append(s.Nodes, SavedNode{
+			URL:        n.URL,
+			Sitemap:    n.sitemap,
+			LinkedFrom: append([]Link{}, n.linkedFrom...),
+			Children:   append([]string{}, n.children...),
+		})
+	}
+	sort.Slice(s.Nodes, func(i, j int) bool { return s.Nodes[i].URL < s.Nodes[j].URL })
+	return s
 }
@@ -15,44 +15,107 @@ func init() {
 	}
 }
 
-const expected = `root
-├── a
-└── b
-`
-
 var _ = Describe("shared tree", func() {
 	Context("Insert into empty tree", func() {
 		var t *Tree
 		BeforeEach(func() {
 			t = New()
 			t.Run()
-			t.AddAt(nil, "root")
+			t.AddRoot("root")
 			t.Quit()
 		})
 		It("added the item", func() {
 			// Can whitebox because we're not sharing yet
-			Expect(t.tree).ToNot(BeNil())
-			Expect((*t.tree).Print()).To(Equal("root\n"))
-		})
-		Context("insert multiple items", func() {
-			var t *Tree
-			var answer string
-			BeforeEach(func() {
-				t = New()
-				t.Run()
-				root := t.AddAt(nil, "root")
-				_ = t.AddAt(root, "a")
-				_ = t.AddAt(root, "b")
-				answer = t.Format()
-				t.Quit()
-			})
-			It("added all the items properly", func() {
-				Expect(t.tree).ToNot(BeNil())
-				Expect((*t.tree).Print()).To(Equal(expected))
-			})
-			It("sends back a correctly-formatted tree", func() {
-				Expect(answer).To(Equal(expected))
-			})
+			Expect(t.root).To(Equal("root"))
+			Expect(t.nodes).To(HaveKey("root"))
+		})
+	})
+	Context("insert multiple items", func() {
+		var t *Tree
+		var answer *Node
+		var added []bool
+		BeforeEach(func() {
+			t = New()
+			t.Run()
+			t.AddRoot("root")
+			added = []bool{
+				t.AddLink("root", "a", "A"),
+				t.AddSitemap("root", "b"),
+				t.AddLink("a", "c", "C"),
+				// Seen before: these are links, not new nodes.
+				t.AddLink("a", "b", "B"),
+				t.AddLink("c", "a", "back to A"),
+				t.AddLink("c", "a", "back to A"),
+				t.AddLink("c", "root", "home"),
+			}
+			answer = t.Copy()
+			t.Quit()
+		})
+		It("says which items are new", func() {
+			Expect(added).To(Equal([]bool{true, true, true, false, false, false, false}))
+		})
+		It("puts each URL in the tree once, under the page it was found on first", func() {
+			Expect(answer).To(Equal(&Node{
+				URL:        "root",
+				LinkedFrom: []Link{{Source: "c", Text: "home"}},
+				Children: []*Node{
+					{
+						URL: "a",
+						LinkedFrom: []Link{
+							{Source: "root", Text: "A"},
+							{Source: "c", Text: "back to A"},
+						},
+						Children: []*Node{
+							{URL: "c", LinkedFrom: []Link{{Source: "a", Text: "C"}}},
+						},
+					},
+					{URL: "b", Sitemap: true, LinkedFrom: []Link{{Source: "a", Text: "B"}}},
+				},
+			}))
+		})
+		It("sends back a copy of the tree", func() {
+			answer.Children[0].URL = "changed"
+			Expect(t.nodes["a"].URL).To(Equal("a"))
+		})
+	})
+	Context("save and load", func() {
+		It("loads the same graph", func() {
+			t := New()
+			t.Run()
+			t.AddRoot("root")
+			t.AddLink("root", "a", "A")
+			t.AddSitemap("root", "b")
+			t.AddLink("a", "root", "home")
+			saved := t.Save()
+			want := t.Copy()
+			t.Quit()
+
+			loaded := Load(saved)
+			loaded.Run()
+			defer loaded.Quit()
+			Expect(loaded.Copy()).To(Equal(want))
+			Expect(loaded.AddLink("a", "b", "B")).To(BeFalse())
+			Expect(loaded.AddLink("a", "c", "C")).To(BeTrue())
+		})
+	})
+	Context("after quitting", func() {
+		It("answers without waiting", func() {
+			t := New()
+			t.Run()
+			t.AddRoot("root")
+			t.Quit()
+			t.Quit()
+			Eventually(t.Copy).Should(BeNil())
+			Expect(t.AddLink("root", "a", "A")).To(BeFalse())
+			Expect(t.Save()).To(Equal(Saved{}))
+		})
+	})
+	Context("Copy an empty tree", func() {
+		It("sends back nil", func() {
+			t := New()
+			t.Run()
+			defer t.Quit()
+			Expect(t.Copy()).To(BeNil())
 		})
 	})
 })
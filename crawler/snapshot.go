@@ -0,0 +1,194 @@
+package crawler
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/joemcmahon/joe_macmahon_technical_test/crawler/page"
+	sharedTree "github.com/joemcmahon/joe_macmahon_technical_test/crawler/shared-tree"
+	log "github.com/sirupsen/logrus"
+)
+
+// Snapshot is everything needed to pick a crawl up where it left off:
+// the URLs still to be crawled, what happened to the ones that have
+// been, and the link graph. It can be taken while the crawl is running,
+// and is meant to be saved as JSON.
+type Snapshot struct {
+	URL     string
+	Options Options
+	// Visited has an entry for each URL we've tried to crawl,
+	// saying what happened to it.
+	Visited map[string]Visit
+	// Queue is the URLs waiting to be crawled, including any that
+	// were being fetched when the snapshot was taken.
+	Queue      []QueuedURL
+	Graph      sharedTree.Saved
+	Linked     []string
+	Sitemapped []string
+	// AnchorLinks are the links with fragments, to be checked.
+	AnchorLinks []AnchorLink
+	Frontier    []string
+	Fetched     int
+	Limit       string
+	Done        bool
+	// Elapsed is how long the crawl had run, for its timeout.
+	Elapsed time.Duration
+}
+
+// QueuedURL is a URL waiting to be crawled.
+type QueuedURL struct {
+	Source  string
+	URL     string
+	Text    string
+	Sitemap bool
+	Depth   int
+	Asset   page.Asset `json:",omitempty"`
+}
+
+// Snapshot takes a snapshot of the crawl as it stands.
+func (state *State) Snapshot() Snapshot {
+	// Save the graph first: anything added to it after this is still
+	// in the queue or the cache, and will be added again on restore.
+	graph := state.tree.Save()
+
+	state.Lock()
+	defer state.Unlock()
+	snap := Snapshot{
+		URL: state.root,
+		Options: Options{
+			Workers:       state.workers,
+			Politeness:    state.politeness,
+			IgnoreRobots:  state.ignoreRobots,
+			Sitemaps:      state.sitemaps,
+			Limits:        state.limits,
+			Patterns:      state.patterns,
+			Scope:         state.scope,
+			Assets:        state.assets,
+			Normalization: state.normalization,
+		},
+		Visited:     make(map[string]Visit),
+		Graph:       graph,
+		Linked:      keys(state.linked),
+		Sitemapped:  keys(state.sitemapped),
+		Frontier:    keys(state.frontier),
+		AnchorLinks: []AnchorLink{},
+		Fetched:     state.fetched,
+		Limit:       state.Limit,
+		Done:        state.Done,
+	}
+	for link := range state.anchorLinks {
+		snap.AnchorLinks = append(snap.AnchorLinks, link)
+	}
+	if !state.started.IsZero() {
+		snap.Elapsed = time.Since(state.started)
+	}
+	for URL, visit := range state.cache {
+		if visit.Outcome == Fetching {
+			// Being fetched; it's in the active list, so it
+			// goes back on the queue instead, and it'll count
+			// toward the page limit when it's fetched again.
+			if visit.Asset == "" {
+				snap.Fetched--
+			}
+			continue
+		}
+		snap.Visited[URL] = visit
+	}
+
+	// Pages being worked on go first, so they're picked up first on restore.
+	for _, item := range state.active {
+		snap.Queue = append(snap.Queue, queued(item))
+	}
+	// The queue can only be read by emptying it, so put everything
+	// back afterward. Nothing else takes items off while we hold the lock.
+	if n := state.unprocessed.Len(); n > 0 {
+		items, _ := state.unprocessed.Get(n)
+		for _, item := range items {
+			snap.Queue = append(snap.Queue, queued(item.(unprocessedItem)))
+		}
+		state.unprocessed.Put(items...)
+	}
+	return snap
+}
+
+// Restore recreates a crawl from a snapshot. The crawl is ready to be
+// started, and picks up where the snapshot left off. It's an error if
+// the crawl's scope patterns don't compile.
+func Restore(snap Snapshot, f Fetcher) (*State, error) {
+	matcher, err := snap.Options.Patterns.compile()
+	if err != nil {
+		return nil, err
+	}
+	state := newState(f, snap.Options, matcher)
+	state.tree = sharedTree.Load(snap.Graph)
+	state.tree.Run()
+	state.root = snap.URL
+	state.BaseURL = snap.URL
+	if b, err := state.purify(snap.URL); err == nil {
+		state.BaseURL = b
+		u, _ := url.Parse(b)
+		state.site = newSite(state.scope, u)
+	}
+
+	for URL, visit := range snap.Visited {
+		state.cache[URL] = visit
+		// Rebuild the index of page contents, for spotting duplicates.
+		if res := visit.Result; visit.Outcome == Fetched && res != nil && res.Hash != "" {
+			if final, err := state.purify(res.FinalURL); err == nil {
+				state.hashes[res.Hash] = final
+			}
+		}
+	}
+	for _, URL := range snap.Linked {
+		state.linked[URL] = true
+	}
+	for _, URL := range snap.Sitemapped {
+		state.sitemapped[URL] = true
+	}
+	for _, link := range snap.AnchorLinks {
+		state.anchorLinks[link] = true
+	}
+	for _, URL := range snap.Frontier {
+		state.frontier[URL] = true
+	}
+	for _, q := range snap.Queue {
+		state.queued[q.URL] = true
+		state.unprocessed.Put(unprocessedItem{
+			source:      q.Source,
+			URL:         q.URL,
+			text:        q.Text,
+			fromSitemap: q.Sitemap,
+			depth:       q.Depth,
+			asset:       q.Asset,
+		})
+	}
+	state.fetched = snap.Fetched
+	state.Limit = snap.Limit
+	state.Done = snap.Done
+	if snap.Elapsed > 0 {
+		state.started = time.Now().Add(-snap.Elapsed)
+	}
+
+	state.Start, state.Pause, state.Resume, state.Quit, state.Wait = state.controls()
+	log.Debugf("crawl for %s restored, %d URLs queued", snap.URL, len(snap.Queue))
+	return state, nil
+}
+
+func queued(item unprocessedItem) QueuedURL {
+	return QueuedURL{
+		Source:  item.source,
+		URL:     item.URL,
+		Text:    item.text,
+		Sitemap: item.fromSitemap,
+		Depth:   item.depth,
+		Asset:   item.asset,
+	}
+}
+
+func keys(m map[string]bool) []string {
+	list := []string{}
+	for k := range m {
+		list = append(list, k)
+	}
+	return list
+}
@@ -2,14 +2,19 @@ package MockFetcher
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/joemcmahon/joe_macmahon_technical_test/crawler/page"
 )
 
 // fakeFetcher is Fetcher that returns canned results.
 type fakeFetcher map[string]*fakeResult
 
 type fakeResult struct {
-	body string
-	urls []string
+	body  string
+	links []page.Link
 }
 
 // MockFetcher is a URL fetcher that uses a data structure to
@@ -25,44 +30,296 @@ func New() *MockFetcher {
 }
 
 // Fetch looks up a URL in the MockFetcher's map.
-// Returns a fake page and URLs from it if found, error if not.
-func (m *MockFetcher) Fetch(url string) (string, []string, error) {
-	if res, ok := (*m.fake)[url]; ok {
-		return res.body, res.urls, nil
+// Returns a fake page and links from it if found, a 404 if not.
+// Redirects are always followed.
+func (m *MockFetcher) Fetch(url string) *page.Result {
+	return m.FetchWithin(url, nil)
+}
+
+// FetchWithin follows the MockFetcher's redirects as long as follow
+// allows them, and then looks up where they ended up.
+func (m *MockFetcher) FetchWithin(url string, follow func(string) bool) *page.Result {
+	final := url
+	hops := []page.Redirect{}
+	seen := map[string]bool{url: true}
+	for target, ok := redirects[final]; ok; target, ok = redirects[final] {
+		hops = append(hops, page.Redirect{URL: final, Status: http.StatusMovedPermanently, Location: target})
+		switch {
+		case seen[target]:
+			return &page.Result{
+				URL:       url,
+				FinalURL:  final,
+				Redirects: hops,
+				Err:       &page.Error{Kind: page.RedirectLoop, Message: "redirect loop"},
+			}
+		case follow != nil && !follow(target):
+			return &page.Result{URL: url, FinalURL: final, Status: http.StatusMovedPermanently, Redirects: hops}
+		}
+		seen[target] = true
+		final = target
+	}
+	if len(hops) == 0 {
+		hops = nil
+	}
+
+	if res, ok := (*m.fake)[final]; ok {
+		result := &page.Result{
+			URL:         url,
+			FinalURL:    final,
+			Status:      http.StatusOK,
+			ContentType: "text/html; charset=utf-8",
+			Size:        len(res.body),
+			Body:        res.body,
+			Links:       res.links,
+			Anchors:     anchors[final],
+			Redirects:   hops,
+			Canonical:   canonicals[final],
+			Hash:        page.ContentHash(res.body),
+			Meta:        meta(final, res),
+		}
+		if p, ok := perf[final]; ok {
+			result.FirstByte, result.Latency = p.firstByte, p.latency
+			if p.size > 0 {
+				result.Size = p.size
+			}
+		}
+		return result
+	}
+	return &page.Result{
+		URL:       url,
+		FinalURL:  final,
+		Status:    http.StatusNotFound,
+		Redirects: hops,
+		Err:       &page.Error{Kind: page.HTTPError, Message: fmt.Sprintf("not found: %s", final)},
 	}
-	return "", nil, fmt.Errorf("not found: %s", url)
+}
+
+// meta is what a page says about itself: what's in metas, plus what
+// can be worked out from the fake page.
+func meta(url string, res *fakeResult) *page.Meta {
+	m := metas[url]
+	if m.Words == 0 {
+		m.Words = len(strings.Fields(res.body))
+	}
+	for _, link := range res.links {
+		if link.Asset == "" {
+			m.OutboundLinks++
+		}
+	}
+	return &m
+}
+
+// Check looks up an asset in the MockFetcher's assets, and
+// returns its content type if found, a 404 if not.
+func (m *MockFetcher) Check(url string) *page.Result {
+	if contentType, ok := assets[url]; ok {
+		return &page.Result{
+			URL:         url,
+			FinalURL:    url,
+			Status:      http.StatusOK,
+			ContentType: contentType,
+		}
+	}
+	return &page.Result{
+		URL:      url,
+		FinalURL: url,
+		Status:   http.StatusNotFound,
+		Err:      &page.Error{Kind: page.HTTPError, Message: fmt.Sprintf("not found: %s", url)},
+	}
+}
+
+// Sitemap pretends that golang.org has a sitemap, which lists
+// one page that nothing links to.
+func (m *MockFetcher) Sitemap(url string) ([]string, error) {
+	if !strings.HasPrefix(url, "http://golang.org/") {
+		return nil, nil
+	}
+	return []string{
+		"http://golang.org/",
+		"http://golang.org/pkg/",
+		"http://golang.org/doc/",
+	}, nil
+}
+
+// Allowed pretends that every site has a robots.txt which
+// disallows /private/.
+func (m *MockFetcher) Allowed(url string) (bool, time.Duration) {
+	return !strings.Contains(url, "/private/"), 0
 }
 
 // fetcher is a populated fakeFetcher.
 var fetcher = &fakeFetcher{
 	"http://golang.org/": &fakeResult{
 		"The Go Programming Language",
-		[]string{
-			"http://golang.org/pkg/",
-			"http://golang.org/cmd/",
+		[]page.Link{
+			{URL: "http://golang.org/pkg/", Text: "Packages"},
+			{URL: "http://golang.org/cmd/", Text: "Commands"},
+			{URL: "http://golang.org/private/", Text: "Private"},
+			{URL: "http://golang.org/lib/godoc/style.css", Asset: page.Stylesheet},
+			{URL: "http://golang.org/lib/godoc/images/go-logo-blue.svg", Text: "Go", Asset: page.Image},
 		},
 	},
 	"http://golang.org/pkg/": &fakeResult{
 		"Packages",
-		[]string{
-			"http://golang.org/",
-			"http://golang.org/cmd/",
-			"http://golang.org/pkg/fmt/",
-			"http://golang.org/pkg/os/",
+		[]page.Link{
+			{URL: "http://golang.org/", Text: "Home"},
+			{URL: "http://golang.org/cmd/", Text: "Commands"},
+			{URL: "http://golang.org/pkg/fmt/", Text: "fmt"},
+			{URL: "http://golang.org/pkg/os/", Text: "os"},
+			{URL: "http://golang.org/lib/godoc/style.css", Asset: page.Stylesheet},
+			{URL: "http://golang.org/lib/godoc/images/gopher.png", Asset: page.Image},
+		},
+	},
+	"http://golang.org/doc/": &fakeResult{
+		"Documentation",
+		[]page.Link{
+			{URL: "http://golang.org/", Text: "Home"},
 		},
 	},
 	"http://golang.org/pkg/fmt/": &fakeResult{
 		"Package fmt",
-		[]string{
-			"http://golang.org/",
-			"http://golang.org/pkg/",
+		[]page.Link{
+			{URL: "http://golang.org/", Text: "Home"},
+			{URL: "http://golang.org/pkg/#fmt", Text: "Packages"},
+		},
+	},
+	"http://golang.org/blog/": &fakeResult{
+		"The Go Blog",
+		[]page.Link{
+			{URL: "http://golang.org/blog/moved/", Text: "Moved"},
+			{URL: "http://golang.org/blog/renamed/", Text: "Renamed"},
+			{URL: "http://golang.org/blog/loop/", Text: "Loop"},
+			{URL: "http://golang.org/blog/away/", Text: "Away"},
+		},
+	},
+	"http://golang.org/blog/latest/": &fakeResult{
+		"Latest post",
+		[]page.Link{
+			{URL: "http://golang.org/blog/", Text: "Blog"},
+		},
+	},
+	"http://golang.org/news/": &fakeResult{
+		"Go News",
+		[]page.Link{
+			{URL: "http://golang.org/news/?page=1", Text: "Page 1"},
+			{URL: "http://golang.org/news/item/1/", Text: "Item 1"},
+			{URL: "http://golang.org/news/item/?id=1", Text: "Item 1, by id"},
+			{URL: "http://golang.org/news/item/?id=1&ref=home", Text: "Item 1, from home"},
+		},
+	},
+	"http://golang.org/news/?page=1": &fakeResult{
+		"Go  News",
+		[]page.Link{
+			{URL: "http://golang.org/news/?page=2", Text: "Page 2"},
+		},
+	},
+	"http://golang.org/news/item/1/": &fakeResult{
+		"Item 1",
+		[]page.Link{
+			{URL: "http://golang.org/news/", Text: "News"},
+		},
+	},
+	"http://golang.org/news/item/?id=1": &fakeResult{
+		"Item 1, as the CMS shows it",
+		[]page.Link{
+			{URL: "http://golang.org/news/", Text: "News"},
+		},
+	},
+	"http://golang.org/news/item/?id=1&ref=home": &fakeResult{
+		"Item 1, as the CMS shows it",
+		[]page.Link{
+			{URL: "http://golang.org/news/", Text: "News"},
 		},
 	},
 	"http://golang.org/pkg/os/": &fakeResult{
 		"Package os",
-		[]string{
-			"http://golang.org/",
-			"http://golang.org/pkg/",
+		[]page.Link{
+			{URL: "http://golang.org/#top", Text: "Home"},
+			{URL: "http://golang.org/pkg/#syscall", Text: "Packages"},
 		},
 	},
 }
+
+// assets are the assets the pages use that exist, with their content
+// types. The gopher on the packages page is missing.
+var assets = map[string]string{
+	"http://golang.org/lib/godoc/style.css":               "text/css",
+	"http://golang.org/lib/godoc/images/go-logo-blue.svg": "image/svg+xml",
+}
+
+// anchors are the anchors on the pages that have any. The os page
+// links to one on the packages page that isn't there.
+var anchors = map[string][]string{
+	"http://golang.org/pkg/": {"fmt", "os"},
+}
+
+// redirects are the URLs that redirect, and where to. Nothing under
+// golang.org/ links to the blog, so they only turn up in crawls of it.
+var redirects = map[string]string{
+	// Two hops to the latest post, and one.
+	"http://golang.org/blog/moved/":   "http://golang.org/blog/renamed/",
+	"http://golang.org/blog/renamed/": "http://golang.org/blog/latest/",
+	// Round and round.
+	"http://golang.org/blog/loop/":  "http://golang.org/blog/loop2/",
+	"http://golang.org/blog/loop2/": "http://golang.org/blog/loop/",
+	// Off the site.
+	"http://golang.org/blog/away/": "http://blog.golang.org/",
+}
+
+// canonicals are the canonical URLs pages name. The news items the CMS
+// shows under query strings all name the same one; the first page of
+// the news is the same as the news, but doesn't say so.
+var canonicals = map[string]string{
+	"http://golang.org/news/item/1/":             "http://golang.org/news/item/1/",
+	"http://golang.org/news/item/?id=1":          "http://golang.org/news/item/1/",
+	"http://golang.org/news/item/?id=1&ref=home": "http://golang.org/news/item/1/",
+}
+
+// metas are what the golang.org pages say about themselves. The home
+// page is the only one with nothing wrong with it: the packages page
+// has no description and two h1s, the docs have the same title as the
+// packages, and fmt has no title at all. Every other page is thin.
+var metas = map[string]page.Meta{
+	"http://golang.org/": {
+		Title:       "The Go Programming Language",
+		Description: "Go is an open source programming language.",
+		H1s:         []string{"The Go Programming Language"},
+		Language:    "en",
+		Words:       500,
+	},
+	"http://golang.org/pkg/": {
+		Title:    "Packages - The Go Programming Language",
+		H1s:      []string{"Packages", "Standard library"},
+		Language: "en",
+	},
+	"http://golang.org/doc/": {
+		Title:       "Packages - The Go Programming Language",
+		Description: "Documentation for the Go programming language.",
+		H1s:         []string{"Documentation"},
+		Language:    "en",
+	},
+	"http://golang.org/pkg/fmt/": {
+		Description: "Package fmt implements formatted I/O.",
+		H1s:         []string{"Package fmt"},
+		Robots:      "noindex",
+	},
+	"http://golang.org/pkg/os/": {
+		Title:       "os - The Go Programming Language",
+		Description: "Package os provides a platform-independent interface to operating system functionality.",
+		H1s:         []string{"Package os"},
+	},
+}
+
+// perf is how long the golang.org pages take to fetch, and how big
+// big the ones that aren't as small as they look really are. The
+// packages page is the slowest, and the biggest.
+var perf = map[string]struct {
+	firstByte, latency time.Duration
+	size               int
+}{
+	"http://golang.org/":         {80 * time.Millisecond, 120 * time.Millisecond, 40000},
+	"http://golang.org/pkg/":     {600 * time.Millisecond, 900 * time.Millisecond, 250000},
+	"http://golang.org/doc/":     {90 * time.Millisecond, 150 * time.Millisecond, 20000},
+	"http://golang.org/pkg/fmt/": {100 * time.Millisecond, 200 * time.Millisecond, 0},
+	"http://golang.org/pkg/os/":  {150 * time.Millisecond, 300 * time.Millisecond, 60000},
+}
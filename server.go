@@ -5,9 +5,13 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	pb "github.com/joemcmahon/joe_macmahon_technical_test/api/crawl"
 	"github.com/joemcmahon/joe_macmahon_technical_test/api/server"
+	"github.com/joemcmahon/joe_macmahon_technical_test/crawler"
 	"github.com/joemcmahon/joe_macmahon_technical_test/crawler/fetcher"
 	"github.com/joemcmahon/joe_macmahon_technical_test/crawler/test/mock_fetcher"
 	"github.com/joemcmahon/joe_macmahon_technical_test/testdata"
@@ -23,6 +27,13 @@ var (
 	port     = flag.Int("port", 10000, "The server port")
 	debug    = flag.Bool("debug", false, "Turn on server debug")
 	mock     = flag.Bool("mock", false, "Use the mock fetcher for testing")
+	workers  = flag.Int("workers", crawler.DefaultWorkers, "Default number of parallel fetches per crawl")
+	rate     = flag.Float64("rate", 5, "Default maximum requests per second to any one host (0 for no limit)")
+	burst    = flag.Int("burst", 5, "Default number of requests to a host that may be made at once")
+	minDelay = flag.Duration("min_delay", 0, "Default minimum delay between requests to the same host")
+	agent    = flag.String("user_agent", Fetcher.DefaultUserAgent, "User agent to crawl as, and to look for in robots.txt")
+	dataDir  = flag.String("data_dir", "crawl-data", "Directory crawls are saved in, to survive restarts (empty to not save them)")
+	interval = flag.Duration("checkpoint", 30*time.Second, "How often running crawls are saved")
 )
 
 func main() {
@@ -51,18 +62,62 @@ func main() {
 	log.Debug("starting server")
 	grpcServer := grpc.NewServer(opts...)
 	log.Debug("registering crawler")
+	if *dataDir != "" {
+		if err := os.MkdirAll(*dataDir, 0755); err != nil {
+			log.Fatalf("can't create data directory: %v", err)
+		}
+	}
+	config := Server.Config{
+		DataDir: *dataDir,
+		Defaults: crawler.Options{
+			Workers: *workers,
+			Politeness: crawler.Politeness{
+				RequestsPerSecond: *rate,
+				Burst:             *burst,
+				MinDelay:          *minDelay,
+			},
+		},
+	}
+	var s *Server.CrawlServer
 	if *mock {
 		f := MockFetcher.New()
-		pb.RegisterCrawlServer(grpcServer, Server.New(f))
+		s = Server.New(f, config)
 	} else {
-		f := Fetcher.New()
-		pb.RegisterCrawlServer(grpcServer, Server.New(f))
+		f := Fetcher.New(*agent)
+		s = Server.New(f, config)
+	}
+	pb.RegisterCrawlServer(grpcServer, s)
+	if *dataDir != "" {
+		checkpoint(s, grpcServer)
 	}
 	log.Debug("ready")
 	grpcServer.Serve(lis)
 	log.Debug("server terminated")
 }
 
+// checkpoint saves the crawls every so often, and once more when
+// the server is killed.
+func checkpoint(s *Server.CrawlServer, grpcServer *grpc.Server) {
+	ticker := time.NewTicker(*interval)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				log.Debug("checkpointing crawls")
+				s.Checkpoint()
+			case sig := <-signals:
+				log.Infof("%v: saving crawls and exiting", sig)
+				ticker.Stop()
+				grpcServer.Stop()
+				s.Checkpoint()
+				os.Exit(0)
+			}
+		}
+	}()
+}
+
 func debugging() bool {
 	return *debug || os.Getenv("TESTING") != ""
 }